@@ -1,14 +1,16 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/johnsto/go-highlight"
-	_ "github.com/johnsto/go-highlight/lexers"
+	"github.com/johnsto/go-highlight/lexers"
 	"github.com/johnsto/go-highlight/output"
 	"github.com/johnsto/go-highlight/output/term"
 	"github.com/spf13/pflag"
@@ -28,6 +30,69 @@ func listSupportedTypes(w io.Writer) {
 		patterns := tokenizer.ListFilenames()
 		fmt.Fprintf(w, "  %s: %q\n", name, patterns)
 	}
+
+	fmt.Fprintln(w, "\nRegistered aliases:")
+	for name, tokenizer := range tokenizers {
+		if aliases := tokenizer.ListAliases(); len(aliases) > 0 {
+			fmt.Fprintf(w, "  %s: %q\n", name, aliases)
+		}
+	}
+}
+
+// configureFormatter applies any formatting options given on the command line
+// to the tokenizer's formatter, returning the reconfigured tokenizer.
+func configureFormatter(tokenizer highlight.Tokenizer,
+	flags *pflag.FlagSet) highlight.Tokenizer {
+	lexer, ok := tokenizer.(highlight.Lexer)
+	if !ok {
+		return tokenizer
+	}
+	jf, ok := lexer.Formatter.(*lexers.JSONFormatter)
+	if !ok {
+		return tokenizer
+	}
+
+	f := *jf
+	if flags.Changed("indent") {
+		n, _ := flags.GetInt("indent")
+		f.Indent = strings.Repeat(" ", n)
+	}
+	if tabs, _ := flags.GetBool("tabs"); tabs {
+		f.Indent = "\t"
+	}
+	if flags.Changed("width") {
+		f.Width, _ = flags.GetInt("width")
+	}
+	if flags.Changed("sort-keys") {
+		f.SortKeys, _ = flags.GetBool("sort-keys")
+	}
+	if flags.Changed("expand-empty") {
+		f.ExpandEmpty, _ = flags.GetBool("expand-empty")
+	}
+	if flags.Changed("trailing-newline") {
+		f.TrailingNewline, _ = flags.GetBool("trailing-newline")
+	}
+	lexer.Formatter = &f
+	return lexer
+}
+
+// canValidate returns true if the tokenizer checks the structure of its
+// input when validating, rather than only tokenizing it.
+func canValidate(tokenizer highlight.Tokenizer) bool {
+	lexer, ok := tokenizer.(highlight.Lexer)
+	return ok && lexer.Validator != nil
+}
+
+// tokenizerName returns the name of the tokenizer, or failing that, the
+// content type or file it was chosen for.
+func tokenizerName(tokenizer highlight.Tokenizer, contentType,
+	filename string) string {
+	if lexer, ok := tokenizer.(highlight.Lexer); ok {
+		return lexer.Name
+	} else if contentType != "" {
+		return contentType
+	}
+	return path.Base(filename)
 }
 
 func main() {
@@ -42,11 +107,29 @@ func main() {
 	}
 
 	contentType := pflag.StringP("type", "t", "",
-		"content type to parse as (e.g. 'application/json')")
+		"content type or lexer name to parse as (e.g. 'application/json' "+
+			"or 'postgres')")
 	outputType := pflag.StringP("output", "o", "ansi", "output type [ansi] "+
 		"<ansi|text|debug>")
 	outputFile := pflag.StringP("output-file", "O", "", "output to file")
 	listSupported := pflag.BoolP("list", "l", false, "list supported types")
+	minify := pflag.BoolP("minify", "m", false,
+		"minify output, removing insignificant whitespace and comments")
+	validate := pflag.Bool("validate", false, "check the structure of the "+
+		"input, exiting with a non-zero status if it is invalid")
+	paths := pflag.Bool("paths", false, "annotate each token with its "+
+		"JSON path, shown in debug output")
+	diffSyntax := pflag.Bool("diff-syntax", false, "highlight the lines of "+
+		"a diff according to the type of file being patched")
+	pflag.Int("indent", 2, "number of spaces to indent formatted output by")
+	pflag.Bool("tabs", false, "indent formatted output with tabs")
+	pflag.Int("width", 0, "write arrays and objects that fit within this "+
+		"many characters on a single line")
+	pflag.Bool("sort-keys", false, "sort object keys in formatted output")
+	pflag.Bool("expand-empty", false, "write empty arrays and objects "+
+		"across two lines")
+	pflag.Bool("trailing-newline", false, "end formatted output with a "+
+		"newline")
 
 	pflag.Parse()
 
@@ -66,7 +149,9 @@ func main() {
 	case "text":
 		outputter = output.NewTextOutputter()
 	case "debug":
-		outputter = output.NewDebugOutputter()
+		debug := output.NewDebugOutputter()
+		debug.ShowPath = *paths
+		outputter = debug
 	default:
 		fmt.Fprintf(os.Stderr,
 			"unknown output type '%s'. Valid values are:\n"+
@@ -83,7 +168,15 @@ func main() {
 	var err error
 
 	// If user has specified a content type, resolve that first.
-	if *contentType != "" {
+	if *contentType != "" && !strings.Contains(*contentType, "/") {
+		tokenizer = highlight.GetTokenizer(*contentType)
+		if tokenizer == nil {
+			fmt.Fprintf(os.Stderr, "couldn't find tokenizer named '%s'\n",
+				*contentType)
+			os.Exit(1)
+			return
+		}
+	} else if *contentType != "" {
 		tokenizer, err = highlight.GetTokenizerForContentType(*contentType)
 		if err != nil {
 			fmt.Fprintf(os.Stderr,
@@ -137,6 +230,13 @@ func main() {
 		}
 	}
 
+	if *validate && !canValidate(tokenizer) {
+		fmt.Fprintf(os.Stderr, "validation not supported for %s\n",
+			tokenizerName(tokenizer, *contentType, filename))
+		os.Exit(1)
+		return
+	}
+
 	// Write output to file if specified
 	if *outputFile != "" {
 		f, err := os.Create(*outputFile)
@@ -146,7 +246,30 @@ func main() {
 		outputter.SetFile(f)
 	}
 
-	err = tokenizer.Format(r, outputter.Emit)
+	tokenizer = configureFormatter(tokenizer, pflag.CommandLine)
+
+	emit := outputter.Emit
+	if *paths {
+		emit = lexers.JSONPathFilter.Filter(emit)
+	}
+	if *diffSyntax {
+		emit = lexers.DiffSyntaxFilter.Filter(emit)
+	}
+
+	br := bufio.NewReader(r)
+	if *validate {
+		err = tokenizer.Validate(br, emit)
+		if errs, ok := err.(highlight.ValidationErrors); ok {
+			for _, e := range errs {
+				fmt.Fprintln(os.Stderr, e)
+			}
+			os.Exit(1)
+		}
+	} else if *minify {
+		err = tokenizer.Minify(br, emit)
+	} else {
+		err = tokenizer.Format(br, emit)
+	}
 	if err != nil && err != io.EOF {
 		log.Fatalln(err)
 	}
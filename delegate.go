@@ -0,0 +1,163 @@
+package highlight
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// DelegateFilter re-tokenizes parts of the input using another Tokenizer,
+// allowing one language to be embedded within another, such as the body of
+// an HTTP message, or CSS within an HTML <style> element.
+//
+// The values of consecutive tokens emitted from any of States are collected,
+// and once the lexer moves on they are tokenized as a single block by the
+// Tokenizer chosen by Select. All other tokens are emitted unmodified.
+//
+// Tokens from any of Gaps may interrupt a block without ending it, such as
+// the chunk sizes within the body of an HTTP message. They are emitted in
+// place, between the tokens of the block surrounding them.
+type DelegateFilter struct {
+	// States lists the states whose tokens are delegated.
+	States []string
+	// Types optionally restricts delegation to tokens of the given types,
+	// allowing a state to contain rules that are not delegated.
+	Types []TokenType
+	// Gaps lists the states whose tokens may interrupt a block.
+	Gaps []string
+	// Select is called with each token that is not delegated, nor a gap
+	// within a block, along with the currently selected Tokenizer, and
+	// returns the Tokenizer to use for subsequent blocks. If no Tokenizer is
+	// selected, delegated tokens are emitted unmodified.
+	Select func(t Token, current Tokenizer) Tokenizer
+}
+
+// gap returns true if the given token may interrupt a block.
+func (f DelegateFilter) gap(t Token) bool {
+	for _, state := range f.Gaps {
+		if t.State == state {
+			return true
+		}
+	}
+	return false
+}
+
+// delegates returns true if the given token should be delegated.
+func (f DelegateFilter) delegates(t Token) bool {
+	if t == EndToken {
+		return false
+	}
+	found := false
+	for _, state := range f.States {
+		if t.State == state {
+			found = true
+			break
+		}
+	}
+	if !found || len(f.Types) == 0 {
+		return found
+	}
+	for _, tt := range f.Types {
+		if t.Type == tt {
+			return true
+		}
+	}
+	return false
+}
+
+func (f DelegateFilter) Filter(out func(Token) error) func(Token) error {
+	var tokenizer Tokenizer
+	// block holds the tokens awaiting delegation, and any gaps within them
+	var block []Token
+	// gaps holds the gaps following the last token awaiting delegation
+	var gaps []Token
+
+	flush := func() error {
+		tokens := append(block, gaps...)
+		block, gaps = nil, nil
+
+		if tokenizer == nil {
+			for _, t := range tokens {
+				if err := out(t); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		// inner holds the gaps within the block, and offsets the position
+		// within the delegated text at which each occurs
+		var b strings.Builder
+		var inner []Token
+		var offsets []int
+		for _, t := range tokens {
+			if f.delegates(t) {
+				b.WriteString(t.Value)
+			} else {
+				inner = append(inner, t)
+				offsets = append(offsets, b.Len())
+			}
+		}
+		// emitGaps emits the gaps at or before the given offset
+		emitGaps := func(offset int) error {
+			for len(inner) > 0 && offsets[0] <= offset {
+				if err := out(inner[0]); err != nil {
+					return err
+				}
+				inner, offsets = inner[1:], offsets[1:]
+			}
+			return nil
+		}
+
+		pos := 0
+		r := bufio.NewReader(strings.NewReader(b.String()))
+		err := tokenizer.Tokenize(r, func(t Token) error {
+			if t == EndToken {
+				return nil
+			}
+			for {
+				if err := emitGaps(pos); err != nil {
+					return err
+				}
+				if len(inner) == 0 || offsets[0] >= pos+len(t.Value) {
+					pos += len(t.Value)
+					return out(t)
+				}
+				// Split the token at the next gap
+				head := t
+				head.Value = t.Value[:offsets[0]-pos]
+				if err := out(head); err != nil {
+					return err
+				}
+				t.Value = t.Value[offsets[0]-pos:]
+				pos = offsets[0]
+			}
+		})
+		if err != nil && err != io.EOF {
+			return err
+		}
+		return emitGaps(b.Len())
+	}
+
+	return func(t Token) error {
+		if f.delegates(t) {
+			block = append(block, gaps...)
+			block = append(block, t)
+			gaps = nil
+			return nil
+		}
+		if len(block) > 0 && f.gap(t) {
+			gaps = append(gaps, t)
+			return nil
+		}
+		if len(block) > 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if t != EndToken && f.Select != nil {
+			tokenizer = f.Select(t, tokenizer)
+		}
+		return out(t)
+	}
+}
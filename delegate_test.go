@@ -0,0 +1,112 @@
+package highlight_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+func TestDelegateFilter(t *testing.T) {
+	inner := Lexer{
+		Name: "inner",
+		States: StatesSpec{
+			"root": {
+				{Regexp: `[0-9]+`, Type: Number},
+				{Regexp: `\s+`, Type: Whitespace},
+			},
+		},
+	}
+
+	outer := Lexer{
+		Name: "outer",
+		States: StatesSpec{
+			"root": {
+				{Regexp: `<`, Type: Punctuation, State: "embedded"},
+				{Regexp: `[^<]+`, Type: Text},
+			},
+			"embedded": {
+				{Regexp: `>`, Type: Punctuation, State: "#pop"},
+				{Regexp: `[^>]+`, Type: Text},
+			},
+		},
+		Filters: Filters{DelegateFilter{
+			States: []string{"embedded"},
+			Types:  []TokenType{Text},
+			Select: func(t Token, current Tokenizer) Tokenizer {
+				if t.Value == "<" {
+					return inner
+				}
+				return current
+			},
+		}},
+	}
+
+	tokens, err := outer.TokenizeString("a <1 2\n3> b")
+	assert.Equal(t, []Token{
+		{Value: "a ", Type: Text, State: "root"},
+		{Value: "<", Type: Punctuation, State: "root"},
+		{Value: "1", Type: Number, State: "root"},
+		{Value: " ", Type: Whitespace, State: "root"},
+		{Value: "2", Type: Number, State: "root"},
+		{Value: "\n", Type: Whitespace, State: "root"},
+		{Value: "3", Type: Number, State: "root"},
+		{Value: ">", Type: Punctuation, State: "embedded"},
+		{Value: " b", Type: Text, State: "root"},
+		EndToken,
+	}, tokens)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDelegateFilterGaps(t *testing.T) {
+	inner := Lexer{
+		Name: "inner",
+		States: StatesSpec{
+			"root": {
+				{Regexp: `[0-9]+`, Type: Number},
+				{Regexp: `\s+`, Type: Whitespace},
+			},
+		},
+	}
+
+	// Bars within the embedded text are gaps, and so split its tokens
+	outer := Lexer{
+		Name: "outer",
+		States: StatesSpec{
+			"root": {
+				{Regexp: `<`, Type: Punctuation, State: "embedded"},
+				{Regexp: `[^<]+`, Type: Text},
+			},
+			"embedded": {
+				{Regexp: `>`, Type: Punctuation, State: "#pop"},
+				{Regexp: `\|`, Type: Punctuation},
+				{Regexp: `[^>|]+`, Type: Text},
+			},
+		},
+		Filters: Filters{DelegateFilter{
+			States: []string{"embedded"},
+			Types:  []TokenType{Text},
+			Gaps:   []string{"embedded"},
+			Select: func(t Token, current Tokenizer) Tokenizer {
+				return inner
+			},
+		}},
+	}
+
+	tokens, err := outer.TokenizeString("<1|2 |3> b")
+	assert.Equal(t, []Token{
+		{Value: "<", Type: Punctuation, State: "root"},
+		{Value: "1", Type: Number, State: "root"},
+		{Value: "|", Type: Punctuation, State: "embedded"},
+		{Value: "2", Type: Number, State: "root"},
+		{Value: " ", Type: Whitespace, State: "root"},
+		{Value: "|", Type: Punctuation, State: "embedded"},
+		{Value: "3", Type: Number, State: "root"},
+		{Value: ">", Type: Punctuation, State: "embedded"},
+		{Value: " b", Type: Text, State: "root"},
+		EndToken,
+	}, tokens)
+	assert.Equal(t, io.EOF, err)
+}
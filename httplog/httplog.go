@@ -0,0 +1,321 @@
+// Package httplog provides HTTP client and server middleware that write
+// highlighted dumps of each request and response to an Outputter, for use
+// when debugging.
+//
+// Dumps are tokenized using the registered "http" Tokenizer, and bodies
+// using the Tokenizer registered for their Content-Type, so remember to
+// import the lexers package too:
+//
+//	import _ "github.com/johnsto/go-highlight/lexers"
+package httplog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/output"
+)
+
+// DefaultMaxBodySize is the number of bytes of each body captured by a new
+// Logger.
+const DefaultMaxBodySize = 64 * 1024
+
+// Redacted replaces the value of any redacted header.
+const Redacted = "[REDACTED]"
+
+// Logger writes highlighted dumps of HTTP requests and responses.
+type Logger struct {
+	// Output receives the tokens of each dump.
+	Output output.Outputter
+	// Format formats each body, e.g. indenting JSON, where supported by
+	// the body's Tokenizer.
+	Format bool
+	// MaxBodySize is the maximum number of bytes of each body to capture.
+	// Longer bodies are truncated. If zero, bodies are omitted entirely.
+	MaxBodySize int64
+	// Redact lists the headers whose values should not be written.
+	Redact []string
+
+	// mu ensures that concurrent dumps are not interleaved
+	mu sync.Mutex
+}
+
+// NewLogger returns a Logger writing to the given Outputter, which redacts
+// common credential headers.
+func NewLogger(o output.Outputter) *Logger {
+	return &Logger{
+		Output:      o,
+		MaxBodySize: DefaultMaxBodySize,
+		Redact: []string{"Authorization", "Proxy-Authorization", "Cookie",
+			"Set-Cookie"},
+	}
+}
+
+// Transport returns an http.RoundTripper that logs each request made using
+// `next`, and the response received. If `next` is nil,
+// http.DefaultTransport is used.
+//
+// Each exchange is logged once the response body has been read to the end
+// or closed, so callers must close it as usual.
+func (l *Logger) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripper{logger: l, next: next}
+}
+
+// Handler returns an http.Handler that logs each request received by
+// `next`, and the response written.
+func (l *Logger) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody *capture
+		r.Body, reqBody = l.capture(r.Body)
+
+		rec := &recorder{ResponseWriter: w, limit: l.MaxBodySize}
+		next.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+
+		start := fmt.Sprintf("%s %s %s", r.Method, r.RequestURI, r.Proto)
+		header := r.Header.Clone()
+		if r.Host != "" {
+			header.Set("Host", r.Host)
+		}
+		status := fmt.Sprintf("%s %d %s", r.Proto, rec.status,
+			http.StatusText(rec.status))
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.dump(start, header, reqBody)
+		l.dump(status, rec.Header(), &rec.body)
+	})
+}
+
+type roundTripper struct {
+	logger *Logger
+	next   http.RoundTripper
+}
+
+func (rt roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	l := rt.logger
+
+	// Don't modify the caller's request
+	req = req.Clone(req.Context())
+	var reqBody *capture
+	req.Body, reqBody = l.capture(req.Body)
+
+	start := fmt.Sprintf("%s %s %s", req.Method, req.URL.RequestURI(),
+		req.Proto)
+	header := req.Header.Clone()
+	if req.Host != "" {
+		header.Set("Host", req.Host)
+	} else {
+		header.Set("Host", req.URL.Host)
+	}
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.dump(start, header, reqBody)
+		l.emit(highlight.Token{Value: err.Error() + "\n",
+			Type: highlight.Error})
+		return resp, err
+	}
+
+	status := fmt.Sprintf("%s %s", resp.Proto, resp.Status)
+	respHeader := resp.Header.Clone()
+	dump := func(respBody *capture) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.dump(start, header, reqBody)
+		l.dump(status, respHeader, respBody)
+	}
+
+	// Return the response straight away, and dump it once the caller has
+	// finished with the body, so that streamed responses aren't held up
+	var respBody *capture
+	resp.Body, respBody = l.capture(resp.Body)
+	if respBody == nil {
+		dump(nil)
+	} else {
+		respBody.done = func() { dump(respBody) }
+	}
+	return resp, nil
+}
+
+// dump writes the given message to the Outputter.
+func (l *Logger) dump(start string, header http.Header, body *capture) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s\r\n", start)
+
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range header[k] {
+			if l.redacted(k) {
+				v = Redacted
+			}
+			fmt.Fprintf(&b, "%s: %s\r\n", k, v)
+		}
+	}
+	b.WriteString("\r\n")
+
+	// Highlight the start line and headers...
+	l.tokenize(highlight.GetTokenizer("http"), false, b.Bytes())
+
+	// ...then the body
+	if body == nil || body.buf.Len() == 0 {
+		return
+	}
+	tokenizer, _ := highlight.GetTokenizerForContentType(
+		header.Get("Content-Type"))
+	l.tokenize(tokenizer, l.Format, body.buf.Bytes())
+	if body.truncated {
+		l.emit(highlight.Token{Value: "\n[truncated]",
+			Type: highlight.Comment})
+	}
+	l.emit(highlight.Token{Value: "\n", Type: highlight.Whitespace})
+}
+
+// tokenize writes the given data using the given Tokenizer, or as plain text
+// if it is nil.
+func (l *Logger) tokenize(tokenizer highlight.Tokenizer, format bool,
+	data []byte) {
+	if tokenizer == nil {
+		l.emit(highlight.Token{Value: string(data), Type: highlight.Text})
+		return
+	}
+	r := bufio.NewReader(bytes.NewReader(data))
+	emit := func(t highlight.Token) error {
+		if t != highlight.EndToken {
+			l.emit(t)
+		}
+		return nil
+	}
+	if format {
+		tokenizer.Format(r, emit)
+	} else {
+		tokenizer.Tokenize(r, emit)
+	}
+}
+
+// emit writes a single token to the Outputter. Output errors are ignored,
+// so as not to interfere with the traffic being logged.
+func (l *Logger) emit(t highlight.Token) {
+	l.Output.Emit(t)
+}
+
+// redacted returns true if the named header should be redacted.
+func (l *Logger) redacted(name string) bool {
+	for _, r := range l.Redact {
+		if strings.EqualFold(r, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// capture wraps the given body so that the first MaxBodySize bytes read are
+// also captured.
+func (l *Logger) capture(body io.ReadCloser) (io.ReadCloser, *capture) {
+	if body == nil || body == http.NoBody {
+		return body, nil
+	}
+	c := &capture{ReadCloser: body, limit: l.MaxBodySize}
+	return c, c
+}
+
+// capture records the first `limit` bytes read from a body. If set, `done`
+// is called once the body has been read to the end or closed, after which
+// nothing more is recorded.
+type capture struct {
+	io.ReadCloser
+	buf       bytes.Buffer
+	limit     int64
+	truncated bool
+	done      func()
+	finished  sync.Once
+	closed    bool
+}
+
+func (c *capture) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.record(p[:n])
+	if err != nil {
+		c.finish()
+	}
+	return n, err
+}
+
+func (c *capture) Close() error {
+	err := c.ReadCloser.Close()
+	c.finish()
+	return err
+}
+
+// finish stops recording and calls `done`, if this hasn't happened already.
+func (c *capture) finish() {
+	c.finished.Do(func() {
+		c.closed = true
+		if c.done != nil {
+			c.done()
+		}
+	})
+}
+
+func (c *capture) record(p []byte) {
+	if c.closed {
+		return
+	}
+	if remaining := c.limit - int64(c.buf.Len()); int64(len(p)) > remaining {
+		c.truncated = true
+		if remaining > 0 {
+			c.buf.Write(p[:remaining])
+		}
+	} else {
+		c.buf.Write(p)
+	}
+}
+
+// recorder captures the status and body written to an http.ResponseWriter.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   capture
+	limit  int64
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.limit = r.limit
+	r.body.record(p)
+	return r.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher, if supported by the underlying writer.
+func (r *recorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,119 @@
+package httplog_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/johnsto/go-highlight/httplog"
+	_ "github.com/johnsto/go-highlight/lexers"
+	"github.com/johnsto/go-highlight/output"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "session=secret")
+			io.WriteString(w, `{"ok":true}`)
+		}))
+	defer server.Close()
+
+	var b bytes.Buffer
+	logger := httplog.NewLogger(&output.TextOutputter{Writer: &b})
+	logger.Format = true
+	client := &http.Client{Transport: logger.Transport(nil)}
+
+	req, err := http.NewRequest("POST", server.URL+"/items?x=1",
+		strings.NewReader(`{"name":"a"}`))
+	assert.Nil(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	assert.Nil(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Nil(t, err)
+
+	// The response body is still available to the caller
+	assert.Equal(t, `{"ok":true}`, string(body))
+
+	dump := b.String()
+	assert.Contains(t, dump, "POST /items?x=1 HTTP/1.1\r\n")
+	assert.Contains(t, dump, "Authorization: [REDACTED]\r\n")
+	assert.Contains(t, dump, "{\n  \"name\": \"a\"\n}\n")
+	assert.Contains(t, dump, "HTTP/1.1 200 OK\r\n")
+	assert.Contains(t, dump, "Set-Cookie: [REDACTED]\r\n")
+	assert.Contains(t, dump, "{\n  \"ok\": true\n}\n")
+	assert.NotContains(t, dump, "secret")
+	assert.NotContains(t, dump, "[truncated]")
+}
+
+func TestTransportStreaming(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, "data: 1\n\n")
+			w.(http.Flusher).Flush()
+			<-release
+			io.WriteString(w, "data: 2\n\n")
+		}))
+	defer server.Close()
+
+	var b bytes.Buffer
+	logger := httplog.NewLogger(&output.TextOutputter{Writer: &b})
+	client := &http.Client{Transport: logger.Transport(nil)}
+
+	// The response is returned before the stream ends...
+	resp, err := client.Get(server.URL + "/events")
+	assert.Nil(t, err)
+	event := make([]byte, len("data: 1\n\n"))
+	_, err = io.ReadFull(resp.Body, event)
+	assert.Nil(t, err)
+	assert.Equal(t, "data: 1\n\n", string(event))
+	assert.Equal(t, "", b.String())
+
+	// ...and dumped once the caller has read it to the end
+	close(release)
+	rest, err := io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "data: 2\n\n", string(rest))
+	assert.Contains(t, b.String(), "GET /events HTTP/1.1\r\n")
+	assert.Contains(t, b.String(), "data: 1\n\ndata: 2\n\n")
+}
+
+func TestHandler(t *testing.T) {
+	var b bytes.Buffer
+	logger := httplog.NewLogger(&output.TextOutputter{Writer: &b})
+	logger.MaxBodySize = 5
+	handler := logger.Handler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		}))
+
+	req := httptest.NewRequest("PUT", "/upload", strings.NewReader("0123456789"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// The handler sees the whole body
+	assert.Equal(t, "0123456789", rec.Body.String())
+
+	assert.Equal(t, "PUT /upload HTTP/1.1\r\n"+
+		"Host: example.com\r\n"+
+		"\r\n"+
+		"01234\n[truncated]\n"+
+		"HTTP/1.1 201 Created\r\n"+
+		"Content-Type: text/plain\r\n"+
+		"\r\n"+
+		"01234\n[truncated]\n", b.String())
+}
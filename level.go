@@ -0,0 +1,73 @@
+package highlight
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Severities returned by Severity, from most to least severe.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityNotice  = "notice"
+	SeverityInfo    = "info"
+	SeverityDebug   = "debug"
+)
+
+// Severity returns the severity of the value of a Level token, such as
+// `ERROR`, `warn`, the syslog priority `<34>` or the HTTP status `404`, or
+// an empty string if it isn't recognised.
+func Severity(level string) string {
+	level = strings.ToLower(strings.Trim(level, `"'[]`))
+
+	// Syslog priority, e.g. `<34>`, in which the severity is the priority
+	// modulo 8.
+	if strings.HasPrefix(level, "<") && strings.HasSuffix(level, ">") {
+		pri, err := strconv.Atoi(level[1 : len(level)-1])
+		if err != nil || pri < 0 {
+			return ""
+		}
+		switch pri % 8 {
+		case 0, 1, 2, 3:
+			return SeverityError
+		case 4:
+			return SeverityWarning
+		case 5:
+			return SeverityNotice
+		case 6:
+			return SeverityInfo
+		}
+		return SeverityDebug
+	}
+
+	// HTTP status, e.g. `404`
+	if len(level) == 3 && level[1] >= '0' && level[1] <= '9' &&
+		level[2] >= '0' && level[2] <= '9' {
+		switch level[0] {
+		case '1', '2':
+			return SeverityInfo
+		case '3':
+			return SeverityNotice
+		case '4':
+			return SeverityWarning
+		case '5':
+			return SeverityError
+		}
+		return ""
+	}
+
+	switch level {
+	case "emerg", "emergency", "panic", "alert", "crit", "critical", "fatal",
+		"err", "error", "severe", "e", "f":
+		return SeverityError
+	case "warn", "warning", "w":
+		return SeverityWarning
+	case "notice", "n":
+		return SeverityNotice
+	case "info", "information", "informational", "i":
+		return SeverityInfo
+	case "debug", "trace", "verbose", "fine", "finer", "finest", "d", "t":
+		return SeverityDebug
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package highlight_test
+
+import (
+	"testing"
+
+	"github.com/johnsto/go-highlight"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverity(t *testing.T) {
+	for level, severity := range map[string]string{
+		"ERROR":      highlight.SeverityError,
+		"[crit]":     highlight.SeverityError,
+		"<34>":       highlight.SeverityError,
+		"503":        highlight.SeverityError,
+		"warn":       highlight.SeverityWarning,
+		"404":        highlight.SeverityWarning,
+		"<165>":      highlight.SeverityNotice,
+		"301":        highlight.SeverityNotice,
+		`"info"`:     highlight.SeverityInfo,
+		"200":        highlight.SeverityInfo,
+		"<15>":       highlight.SeverityDebug,
+		"TRACE":      highlight.SeverityDebug,
+		"frobnicate": "",
+		"<x>":        "",
+		"999":        "",
+	} {
+		assert.Equal(t, severity, highlight.Severity(level), level)
+	}
+}
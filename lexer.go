@@ -15,8 +15,40 @@ type Lexer struct {
 	States    States
 	Filters   Filters
 	Formatter Filter
+	Minifier  Filter
+	Validator Filter
 	Filenames []string
 	MimeTypes []string
+	// Aliases lists alternative names the Lexer may be looked up by, e.g.
+	// "postgres" for "postgresql".
+	Aliases []string
+	// IndentStates lists states that end automatically at the first
+	// non-blank line indented no further than the line on which they were
+	// entered, as with YAML block scalars.
+	IndentStates []string
+	// Indent, if set, returns the indentation IndentStates entered on the
+	// given line are measured from. By default this is the number of
+	// leading spaces.
+	Indent func(line string) int
+}
+
+// indentScope records where an indentation-scoped state was entered.
+type indentScope struct {
+	state string
+	// depth is the length of the stack once the state was pushed
+	depth int
+	// indent is the indentation of the line the state was pushed on
+	indent int
+}
+
+// indentScoped returns true if the named state is one of IndentStates.
+func (l Lexer) indentScoped(state string) bool {
+	for _, s := range l.IndentStates {
+		if s == baseStateName(state) {
+			return true
+		}
+	}
+	return false
 }
 
 func (l Lexer) Format(r *bufio.Reader, emit func(Token) error) error {
@@ -26,6 +58,22 @@ func (l Lexer) Format(r *bufio.Reader, emit func(Token) error) error {
 	return l.Tokenize(r, l.Formatter.Filter(emit))
 }
 
+func (l Lexer) Minify(r *bufio.Reader, emit func(Token) error) error {
+	if l.Minifier == nil {
+		return l.Tokenize(r, emit)
+	}
+	return l.Tokenize(r, l.Minifier.Filter(emit))
+}
+
+// Validate behaves exactly as Tokenize, except the tokens are also checked
+// by the Lexer's Validator, if it has one.
+func (l Lexer) Validate(r *bufio.Reader, emit func(Token) error) error {
+	if l.Validator == nil {
+		return l.Tokenize(r, emit)
+	}
+	return l.Tokenize(r, l.Validator.Filter(emit))
+}
+
 // Tokenize reads from the given input and emits tokens to the output channel.
 // Will end on any error from the reader, including io.EOF to signify the end
 // of input.
@@ -40,9 +88,36 @@ func (l Lexer) Tokenize(br *bufio.Reader, emit func(Token) error) error {
 	stack := &Stack{"root"}
 	eol := false
 	var subject = ""
+	// lineStart is set if the next read begins a new line
+	lineStart := true
+	// line and indent are the start and indentation of the current line
+	line := ""
+	indent := 0
+	var scopes []indentScope
 	for {
 		next, err := br.ReadString('\n')
 
+		if lineStart && len(l.IndentStates) > 0 {
+			line = next
+			indent = len(next) - len(strings.TrimLeft(next, " "))
+			if strings.TrimSpace(next) != "" {
+				// Leave any scoped states this line is not indented within
+				for len(scopes) > 0 {
+					scope := scopes[len(scopes)-1]
+					entered := scope.depth <= stack.Len() &&
+						(*stack)[scope.depth-1] == scope.state
+					if entered && indent > scope.indent {
+						break
+					} else if entered {
+						for stack.Len() >= scope.depth {
+							stack.Pop()
+						}
+					}
+					scopes = scopes[:len(scopes)-1]
+				}
+			}
+		}
+
 		if err == bufio.ErrBufferFull {
 			eol = false
 		} else if err == io.EOF {
@@ -52,11 +127,15 @@ func (l Lexer) Tokenize(br *bufio.Reader, emit func(Token) error) error {
 		} else {
 			eol = strings.HasSuffix(next, "\n")
 		}
+		lineStart = eol
 
 		subject = subject + next
 
 		if subject == "" && err == io.EOF {
-			emit(EndToken)
+			// Filters may report problems at the end of input
+			if endErr := emit(EndToken); endErr != nil {
+				return endErr
+			}
 			return err
 		}
 
@@ -77,15 +156,19 @@ func (l Lexer) Tokenize(br *bufio.Reader, emit func(Token) error) error {
 					// Read more data for the current line
 					break
 				} else {
-					// Emit entire subject as an error
-					tokens = []Token{{Value: subject, Type: Error}}
-					n = len(subject)
+					// Emit the rest of the line as an error, leaving the
+					// line ending to be matched as normal
+					n = len(strings.TrimRight(subject, "\r\n"))
+					if n == 0 {
+						n = len(subject)
+					}
+					tokens = []Token{{Value: subject[:n], Type: Error}}
 				}
 			}
 
 			// Emit each token to the output
 			for _, t := range tokens {
-				t.State = stateName
+				t.State = baseStateName(stateName)
 				if err := emit(t); err != nil {
 					emit(EndToken)
 					return err
@@ -104,8 +187,23 @@ func (l Lexer) Tokenize(br *bufio.Reader, emit func(Token) error) error {
 				for _, state := range rule.Stack() {
 					if state == "#pop" {
 						stack.Pop()
+					} else if state == "#reset" {
+						stack.Empty()
+						stack.Push("root")
+						scopes = nil
 					} else if state != "" {
 						stack.Push(state)
+						if l.indentScoped(state) {
+							scope := indentScope{
+								state:  state,
+								depth:  stack.Len(),
+								indent: indent,
+							}
+							if l.Indent != nil {
+								scope.indent = l.Indent(line)
+							}
+							scopes = append(scopes, scope)
+						}
 					}
 				}
 			}
@@ -118,8 +216,6 @@ func (l Lexer) Tokenize(br *bufio.Reader, emit func(Token) error) error {
 			subject = subject[n:]
 		}
 	}
-
-	return nil
 }
 
 // TokenizeString is a convenience method
@@ -170,6 +266,11 @@ func (l Lexer) ListMediaTypes() []string {
 	return l.MimeTypes
 }
 
+// ListAliases lists the alternative names of this Lexer, e.g. ["postgres"]
+func (l Lexer) ListAliases() []string {
+	return l.Aliases
+}
+
 // ListFilenames lists the filename patterns this Lexer supports,
 // e.g. ["*.json"]
 func (l Lexer) ListFilenames() []string {
@@ -0,0 +1,79 @@
+package highlight_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+func TestLexerIndentStates(t *testing.T) {
+	lexer := Lexer{
+		Name: "indent",
+		States: StatesSpec{
+			"root": {
+				{Regexp: `\s+`, Type: Whitespace},
+				{Regexp: `(\w+)(:\n)`, SubTypes: []TokenType{Attribute,
+					Punctuation}, State: "block"},
+				{Regexp: `\w+`, Type: Text},
+			},
+			"block": {
+				{Regexp: `[^\n]+`, Type: String},
+				{Regexp: `\n`, Type: Whitespace},
+			},
+		},
+		IndentStates: []string{"block"},
+	}
+
+	tokens, err := lexer.TokenizeString("a:\n  b\n\n  c\nd\n")
+	assert.Equal(t, []Token{
+		{Value: "a", Type: Attribute, State: "root"},
+		{Value: ":\n", Type: Punctuation, State: "root"},
+		{Value: "  b", Type: String, State: "block"},
+		{Value: "\n", Type: Whitespace, State: "block"},
+		{Value: "\n", Type: Whitespace, State: "block"},
+		{Value: "  c", Type: String, State: "block"},
+		{Value: "\n", Type: Whitespace, State: "block"},
+		{Value: "d", Type: Text, State: "root"},
+		{Value: "\n", Type: Whitespace, State: "root"},
+		EndToken,
+	}, tokens)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestLexerCapture(t *testing.T) {
+	lexer := Lexer{
+		Name: "capture",
+		States: StatesSpec{
+			"root": {
+				{Regexp: `(<<)(\w+)`, SubTypes: []TokenType{Operator, String},
+					Capture: 2, State: "body"},
+				{Regexp: `\s+`, Type: Whitespace},
+				{Regexp: `\w+`, Type: Text},
+			},
+			// body ends at a line consisting of the captured word
+			"body": {
+				{Regexp: `^({captured})(\n|$)`,
+					SubTypes: []TokenType{String, Whitespace}, State: "#pop"},
+				{Regexp: `[^\n]+`, Type: String},
+				{Regexp: `\n`, Type: Whitespace},
+			},
+		},
+	}
+
+	tokens, err := lexer.TokenizeString("<<end\nEND\nend\nx")
+	assert.Equal(t, []Token{
+		{Value: "<<", Type: Operator, State: "root"},
+		{Value: "end", Type: String, State: "root"},
+		{Value: "\n", Type: Whitespace, State: "body"},
+		{Value: "END", Type: String, State: "body"},
+		{Value: "\n", Type: Whitespace, State: "body"},
+		{Value: "end", Type: String, State: "body"},
+		{Value: "\n", Type: Whitespace, State: "body"},
+		{Value: "x", Type: Text, State: "root"},
+		EndToken,
+	}, tokens)
+	assert.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,93 @@
+package lexers_test
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/output"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false,
+	"regenerate the golden files under testdata")
+
+// TestGolden tokenizes each file under testdata/<lexer>/ using the named
+// Tokenizer, and compares the tokens emitted against those recorded in the
+// accompanying `.golden` file, in the format of the DebugOutputter. Run
+// `go test -update` to regenerate the golden files after changing a lexer,
+// and review the differences before committing them.
+func TestGolden(t *testing.T) {
+	dirs, err := os.ReadDir("testdata")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		tokenizer := GetTokenizer(dir.Name())
+		if !assert.NotNil(t, tokenizer, "no tokenizer named %q",
+			dir.Name()) {
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join("testdata", dir.Name()))
+		if !assert.Nil(t, err) {
+			continue
+		}
+		for _, file := range files {
+			if file.IsDir() || strings.HasSuffix(file.Name(), ".golden") {
+				continue
+			}
+			path := filepath.Join("testdata", dir.Name(), file.Name())
+			t.Run(dir.Name()+"/"+file.Name(), func(t *testing.T) {
+				testGolden(t, tokenizer, path)
+			})
+		}
+	}
+}
+
+// testGolden compares the tokens of the file at path against its golden
+// file, or rewrites the golden file if -update is set.
+func testGolden(t *testing.T, tokenizer Tokenizer, path string) {
+	input, err := os.ReadFile(path)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var dump bytes.Buffer
+	var values strings.Builder
+	debug := output.NewDebugOutputter()
+	debug.Writer = &dump
+	err = tokenizer.Tokenize(bufio.NewReader(bytes.NewReader(input)),
+		func(t Token) error {
+			if t == EndToken {
+				return nil
+			}
+			values.WriteString(t.Value)
+			return debug.Emit(t)
+		})
+	assert.Equal(t, io.EOF, err, "tokenizer should return EOF")
+
+	// Tokens should account for every byte of the input, in order
+	assert.Equal(t, string(input), values.String(),
+		"tokens should reproduce the input")
+
+	golden := path + ".golden"
+	if *update {
+		assert.Nil(t, os.WriteFile(golden, dump.Bytes(), 0644))
+		return
+	}
+	expected, err := os.ReadFile(golden)
+	if !assert.Nil(t, err, "missing golden file; run with -update") {
+		return
+	}
+	assert.Equal(t, string(expected), dump.String(), golden)
+}
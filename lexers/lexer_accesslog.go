@@ -0,0 +1,63 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// AccessLog matches web server access logs in the Common and Combined Log
+// Formats, as written by Apache and nginx, e.g.
+//
+//	127.0.0.1 - fry [01/Jan/3000:00:00:00 +0000] "GET / HTTP/1.1" 200 2326
+//
+// The status of each request is emitted as a Level, so that it may be
+// coloured by its class.
+var AccessLog = Lexer{
+	Name:      "accesslog",
+	Aliases:   []string{"clf", "combined", "apache-access", "nginx-access"},
+	MimeTypes: []string{"text/x-access-log"},
+	Filenames: []string{"access.log", "access_log", "*.access.log",
+		"*-access.log", "*_access.log"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `[ \t]*\r?\n`, Type: Whitespace},
+			// Remote host, identity, user and time
+			{Regexp: `([^\s\[]+)([ \t]+)([^\s\[]+)([ \t]+)([^\s\[]+)` +
+				`([ \t]+)(\[)([^\]\r\n]+)(\])`,
+				SubTypes: []TokenType{Tag, Whitespace, Text, Whitespace, Text,
+					Whitespace, Punctuation, Timestamp, Punctuation},
+				State: "request"},
+			{Regexp: `[^\r\n]+`, Type: Text},
+		},
+		// request matches the request line, e.g. `"GET / HTTP/1.1"`, which
+		// may be malformed or absent if the client sent garbage.
+		"request": {
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `(")([A-Za-z]+)( +)([^ "\r\n]*)( +)(HTTP)(/)([0-9.]+)(")`,
+				SubTypes: []TokenType{Punctuation, Tag, Whitespace, String,
+					Whitespace, Tag, Punctuation, Tag, Punctuation},
+				State: "#pop status"},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"`, Type: String,
+				State: "#pop status"},
+			{Regexp: ``, State: "#pop fields"},
+		},
+		// status matches the status and size of the response
+		"status": {
+			{Regexp: `([ \t]+)([0-9]{3})([ \t]+)([0-9]+|-)`,
+				SubTypes: []TokenType{Whitespace, Level, Whitespace, Number},
+				State:    "#pop fields"},
+			{Regexp: ``, State: "#pop fields"},
+		},
+		// fields matches the referer and user agent of the Combined Log
+		// Format, and any further fields added by custom formats.
+		"fields": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"`, Type: String},
+			{Regexp: `[0-9]+(?:\.[0-9]+)?\b`, Type: Number},
+			{Regexp: `[^\s"]+`, Type: Text},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(AccessLog.Name, AccessLog)
+}
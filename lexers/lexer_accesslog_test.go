@@ -0,0 +1,60 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerAccessLog(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "127.0.0.1", Type: Tag},
+		{Value: " ", Type: Whitespace},
+		{Value: "-", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "fry", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "[", Type: Punctuation},
+		{Value: "01/Jan/3000:00:00:00 +0000", Type: Timestamp},
+		{Value: "]", Type: Punctuation},
+		{Value: " ", Type: Whitespace},
+		{Value: `"`, Type: Punctuation},
+		{Value: "GET", Type: Tag},
+		{Value: " ", Type: Whitespace},
+		{Value: "/", Type: String},
+		{Value: " ", Type: Whitespace},
+		{Value: "HTTP", Type: Tag},
+		{Value: "/", Type: Punctuation},
+		{Value: "1.1", Type: Tag},
+		{Value: `"`, Type: Punctuation},
+		{Value: " ", Type: Whitespace},
+		{Value: "404", Type: Level},
+		{Value: " ", Type: Whitespace},
+		{Value: "2326", Type: Number},
+		{Value: " ", Type: Whitespace},
+		{Value: `"-"`, Type: String},
+		{Value: " ", Type: Whitespace},
+		{Value: `"curl/7.0"`, Type: String},
+		{Value: "\n", Type: Whitespace},
+		{Value: "::1", Type: Tag},
+		{Value: " ", Type: Whitespace},
+		{Value: "-", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "-", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "[", Type: Punctuation},
+		{Value: "01/Jan/3000:00:00:01 +0000", Type: Timestamp},
+		{Value: "]", Type: Punctuation},
+		{Value: " ", Type: Whitespace},
+		{Value: `"\x16\x03"`, Type: String},
+		{Value: " ", Type: Whitespace},
+		{Value: "400", Type: Level},
+		{Value: " ", Type: Whitespace},
+		{Value: "-", Type: Number},
+	}, tokenize(t, lexers.AccessLog,
+		`127.0.0.1 - fry [01/Jan/3000:00:00:00 +0000] "GET / HTTP/1.1" 404 `+
+			`2326 "-" "curl/7.0"`+"\n"+
+			`::1 - - [01/Jan/3000:00:00:01 +0000] "\x16\x03" 400 -`))
+}
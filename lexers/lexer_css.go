@@ -1,6 +1,10 @@
 package lexers
 
-import . "github.com/johnsto/go-highlight"
+import (
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
 
 var CSS = Lexer{
 	Name:      "css",
@@ -9,87 +13,253 @@ var CSS = Lexer{
 	States: StatesSpec{
 		"root": {
 			{Include: "whitespace"},
-			{Include: "singleLineComment"},
-			{Include: "multiLineComment"},
+			{Include: "comment"},
+			{Include: "atRule"},
 			{Include: "selector"},
-			{Include: "declarationBlock"},
+			{Regexp: `\{`, Type: Punctuation, State: "declaration"},
+			{Regexp: `[};]`, Type: Punctuation},
+		},
+		"whitespace": {
+			{Regexp: `[ \r\n\f\t]+`, Type: Whitespace},
 		},
+		"comment": {
+			{Regexp: `\/\*`, Type: Comment, State: "commentContents"},
+		},
+		"commentContents": {
+			{Regexp: `\*/`, Type: Comment, State: "#pop"},
+			{Regexp: `[^*]+|\*`, Type: Comment},
+		},
+		// selector matches selectors, including the keyframe selectors of
+		// `@keyframes` and the `&` of nested rules
 		"selector": {
-			{Regexp: `(\[)([^\]]+)(\])`,
-				SubTypes: []TokenType{Punctuation, Attribute, Punctuation}},
-			{Regexp: `(\.)([-a-zA-Z0-9]+)`,
+			{Regexp: `\[`, Type: Punctuation, State: "attributeSelector"},
+			{Regexp: `([.#])(-?[_a-zA-Z][-\w]*)`,
 				SubTypes: []TokenType{Punctuation, Attribute}},
-			{Regexp: `@[-a-zA-Z0-9]+`, Type: Literal, State: "media"},
-			{Regexp: `>`, Type: Punctuation},
-			{Regexp: `\+`, Type: Punctuation},
-			{Regexp: `:`, Type: Punctuation},
-			{Regexp: `,`, Type: Punctuation},
-			{Regexp: `[-a-zA-Z0-9]+`, Type: Attribute},
+			{Regexp: `(::?)([-\w]+)(\()`,
+				SubTypes: []TokenType{Punctuation, Attribute, Punctuation},
+				State:    "selectorArguments"},
+			{Regexp: `(::?)([-\w]+)`,
+				SubTypes: []TokenType{Punctuation, Attribute}},
+			{Regexp: `([0-9.]+)(%)`, SubTypes: []TokenType{Number, Keyword}},
+			{Regexp: `[>+~,&|]`, Type: Punctuation},
+			{Regexp: `[-\w]+`, Type: Attribute},
 			{Regexp: `\*`, Type: Attribute},
 		},
-		"media": {
-			{Regexp: ` and `, Type: Operator},
-			{Regexp: `,`, Type: Punctuation},
-			{Regexp: `[-a-zA-Z0-9]+`, Type: Attribute},
-			{Regexp: `(\()` + `(\s*)` +
-				`([-a-zA-Z0-9]+)` + `(:)` + `([^\)]+)` +
-				`(\s*)` + `(\))`,
-				SubTypes: []TokenType{Punctuation, Whitespace, Attribute, Assignment, Text, Whitespace, Punctuation}},
+		// attributeSelector matches the contents of `[attr~="value" i]`
+		"attributeSelector": {
+			{Regexp: `\]`, Type: Punctuation, State: "#pop"},
 			{Include: "whitespace"},
-			{Include: "singleLineComment"},
-			{Include: "multiLineComment"},
-			{Regexp: `{`, Type: Punctuation, State: "mediaBlock"},
+			{Regexp: `[~|^$*]?=`, Type: Operator},
+			{Include: "string"},
+			{Regexp: `[-\w]+`, Type: Attribute},
+			{Regexp: `\|`, Type: Punctuation},
 		},
-		"mediaBlock": {
+		// selectorArguments matches the arguments of functional
+		// pseudo-classes, e.g. `:not(.a, .b)` or `:nth-child(2n + 1)`
+		"selectorArguments": {
+			{Regexp: `\)`, Type: Punctuation, State: "#pop"},
 			{Include: "whitespace"},
-			{Include: "singleLineComment"},
-			{Include: "multiLineComment"},
+			{Regexp: `[-+]?[0-9]*n\b(?:\s*[-+]\s*[0-9]+)?|[-+]?[0-9]+\b`,
+				Type: Number},
+			{Include: "string"},
 			{Include: "selector"},
-			{Include: "declarationBlock"},
-			{Regexp: `}`, Type: Punctuation, State: "#pop #pop"},
 		},
-		"ruleValue": {
-			{Regexp: `;`, Type: "Punctuation", State: "#pop"},
-			{Regexp: `.*`, Type: "Text"},
+		// atRule matches the prelude of an at-rule, e.g. `@media screen`,
+		// followed by either a block or a semicolon
+		"atRule": {
+			{Regexp: `@[-\w]+`, Type: Literal, State: "atRulePrelude"},
+		},
+		"atRulePrelude": {
+			{Regexp: `;`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\{`, Type: Punctuation, State: "#pop declaration"},
+			{Regexp: `(and|or|not|only)\b`, Type: Operator},
+			{Regexp: `\(`, Type: Punctuation, State: "condition"},
+			{Regexp: `[.,]`, Type: Punctuation},
+			{Include: "value"},
 		},
-		"declarationBlock": {
-			{Regexp: `{`, Type: Punctuation, State: "declaration"},
+		// condition matches a media query or feature query within
+		// parentheses, e.g. `(min-width: 40em)` or `(display: grid)`
+		"condition": {
+			{Regexp: `\)`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\(`, Type: Punctuation, State: "condition"},
+			{Regexp: `([-\w]+)(\s*)(:)`,
+				SubTypes: []TokenType{Tag, Whitespace, Assignment}},
+			{Regexp: `(and|or|not)\b`, Type: Operator},
+			{Regexp: `[<>]=?|=`, Type: Operator},
+			{Include: "value"},
 		},
+		// declaration matches the contents of a block, which may contain
+		// nested rules as well as declarations
 		"declaration": {
 			{Include: "whitespace"},
-			{Include: "singleLineComment"},
-			{Include: "multiLineComment"},
-			{Regexp: `([a-zA-Z0-9_-]+)(\w*)(:)`,
+			{Include: "comment"},
+			{Regexp: `}`, Type: Punctuation, State: "#pop"},
+			{Regexp: `;`, Type: Punctuation},
+			{Regexp: `([-\w]+)(\s*)(:)`,
 				SubTypes: []TokenType{Tag, Whitespace, Assignment},
 				State:    "declarationValue"},
-			{Regexp: `}`, Type: Punctuation, State: "#pop"},
+			{Include: "atRule"},
 			{Include: "selector"},
-			{Include: "declarationBlock"},
+			{Regexp: `\{`, Type: Punctuation, State: "declaration"},
 		},
 		"declarationValue": {
-			{Regexp: `(")([^"]*)(")`,
-				SubTypes: []TokenType{Punctuation, Text, Punctuation}},
-			{Regexp: `(')([^']*)(')`,
-				SubTypes: []TokenType{Punctuation, Text, Punctuation}},
-			{Regexp: `[^;]+`, Type: Text},
-			{Regexp: `,`, Type: Punctuation},
 			{Regexp: `;`, Type: Punctuation, State: "#pop"},
+			{Regexp: `}`, Type: Punctuation, State: "#pop #pop"},
+			// What looked like a declaration was a nested rule, such as
+			// `a:hover { ... }`
+			{Regexp: `\{`, Type: Punctuation, State: "#pop declaration"},
+			{Regexp: `!\s*important\b`, Type: Literal},
+			{Regexp: `,`, Type: Punctuation},
+			{Include: "value"},
 		},
-		"whitespace": {
-			{Regexp: `[ \r\n\f\t]+`, Type: Whitespace},
-		},
-		"singleLineComment": {
-			{Regexp: `\/\/.*`, Type: Comment},
+		// value matches the components of a value, such as numbers with
+		// units, colours, strings, keywords and functions
+		"value": {
+			{Include: "whitespace"},
+			{Include: "comment"},
+			{Include: "string"},
+			{Regexp: `(url)(\()([^)"']*)(\))`,
+				SubTypes: []TokenType{Attribute, Punctuation, String,
+					Punctuation}},
+			{Regexp: `(-?[_a-zA-Z][-\w]*)(\()`,
+				SubTypes: []TokenType{Attribute, Punctuation},
+				State:    "function"},
+			{Regexp: `#[0-9a-fA-F]{3,8}\b`, Type: Number},
+			{Regexp: `([-+]?(?:[0-9]*\.[0-9]+|[0-9]+)(?:[eE][-+]?[0-9]+)?)` +
+				`(%|[a-zA-Z]+)?`,
+				SubTypes: []TokenType{Number, Keyword}},
+			// Custom properties, e.g. `--main-color`
+			{Regexp: `--[-\w]+`, Type: Attribute},
+			{Regexp: `-?[_a-zA-Z][-\w]*`, Type: Literal},
+			{Regexp: `[/*]`, Type: Operator},
+			{Regexp: `[^;{}\s]`, Type: Text},
 		},
-		"multiLineComment": {
-			{Regexp: `\/\*`, Type: Comment, State: "multiLineCommentContents"},
+		"string": {
+			{Regexp: `(")((?:[^"\\]|\\.)*)(")`,
+				SubTypes: []TokenType{Punctuation, String, Punctuation}},
+			{Regexp: `(')((?:[^'\\]|\\.)*)(')`,
+				SubTypes: []TokenType{Punctuation, String, Punctuation}},
 		},
-		"multiLineCommentContents": {
-			{Regexp: `\*\/`, Type: Comment, State: "#pop"},
-			{Regexp: `(.+?)(\*\/)`, Type: Comment, State: "#pop"},
-			{Regexp: `.+`, Type: Comment},
+		// function matches the arguments of a function, such as `calc()`,
+		// `var()` or `rgb()`
+		"function": {
+			{Regexp: `\)`, Type: Punctuation, State: "#pop"},
+			{Regexp: `(\s+)([-+])(\s+)`,
+				SubTypes: []TokenType{Whitespace, Operator, Whitespace}},
+			{Regexp: `,`, Type: Punctuation},
+			{Regexp: `\(`, Type: Punctuation, State: "function"},
+			{Include: "value"},
 		},
 	},
+	Filters:  []Filter{RemoveEmptiesFilter},
+	Minifier: CSSMinifier,
+}
+
+// CSSMinifier removes comments and insignificant whitespace from CSS tokens.
+// Whitespace is retained only where it separates two words, such as the
+// descendant combinator in `div p`, and is otherwise collapsed to a single
+// space.
+var CSSMinifier = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		// last is the final character emitted so far
+		var last byte
+		// space is set when whitespace has been dropped since `last`
+		space := false
+		// quote holds the opening quote of the current string, if any
+		quote := ""
+		// operator is set after a `+` or `-` operator within a function,
+		// which must be surrounded by whitespace
+		operator := false
+
+		return func(t Token) error {
+			// trailing is set if whitespace was trimmed from the end of t
+			trailing := false
+
+			switch {
+			case t.Type == "":
+				return out(t)
+			case t.Value == "":
+				return nil
+			}
+
+			switch {
+			case quote != "":
+				// Leave string contents untouched
+				if t.Type == Punctuation && t.Value == quote {
+					quote = ""
+				}
+				last = t.Value[len(t.Value)-1]
+				return out(t)
+			case t.Type == Punctuation && (t.Value == `"` || t.Value == "'"):
+				quote = t.Value
+			case t.Type == Whitespace || t.Type == Comment:
+				space = true
+				return nil
+			case t.Type == Text:
+				// Declaration values may contain surrounding whitespace
+				v := strings.TrimLeft(t.Value, cssSpace)
+				space = space || v != t.Value
+				t.Value = strings.TrimRight(v, cssSpace)
+				trailing = t.Value != v
+				t.Value = collapseSpace(t.Value)
+			}
+
+			if t.Value == "" {
+				return nil
+			}
+
+			isOperator := t.Type == Operator && (t.Value == "+" || t.Value == "-")
+			if space && last != 0 && (isOperator || operator ||
+				!strings.ContainsRune(cssNoSpaceAfter, rune(last)) &&
+					!strings.ContainsRune(cssNoSpaceBefore, rune(t.Value[0]))) {
+				if err := out(Token{Value: " ", Type: Whitespace,
+					State: t.State}); err != nil {
+					return err
+				}
+			}
+			space = trailing
+			operator = isOperator
+			last = t.Value[len(t.Value)-1]
+			return out(t)
+		}
+	})
+
+const (
+	// cssSpace lists the characters CSS considers whitespace.
+	cssSpace = " \t\r\n\f"
+	// cssNoSpaceAfter lists characters that never need to be followed by
+	// whitespace.
+	cssNoSpaceAfter = "{};,>~+:("
+	// cssNoSpaceBefore lists characters that never need to be preceded by
+	// whitespace.
+	cssNoSpaceBefore = "{};,>~+)!"
+)
+
+// collapseSpace replaces each run of whitespace in s with a single space,
+// leaving the contents of quoted strings untouched.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	var quote rune
+	space := false
+	for _, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case strings.ContainsRune(cssSpace, c):
+			space = true
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
 }
 
 func init() {
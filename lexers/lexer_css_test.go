@@ -0,0 +1,171 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerCSS(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		// Selectors
+		{"#a.b>c::after,[d|='e' i]", []Token{
+			{Value: "#", Type: Punctuation},
+			{Value: "a", Type: Attribute},
+			{Value: ".", Type: Punctuation},
+			{Value: "b", Type: Attribute},
+			{Value: ">", Type: Punctuation},
+			{Value: "c", Type: Attribute},
+			{Value: "::", Type: Punctuation},
+			{Value: "after", Type: Attribute},
+			{Value: ",", Type: Punctuation},
+			{Value: "[", Type: Punctuation},
+			{Value: "d", Type: Attribute},
+			{Value: "|=", Type: Operator},
+			{Value: "'", Type: Punctuation},
+			{Value: "e", Type: String},
+			{Value: "'", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "i", Type: Attribute},
+			{Value: "]", Type: Punctuation},
+		}},
+		{"li:nth-child(2n+1):not(.x)", []Token{
+			{Value: "li", Type: Attribute},
+			{Value: ":", Type: Punctuation},
+			{Value: "nth-child", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: "2n+1", Type: Number},
+			{Value: ")", Type: Punctuation},
+			{Value: ":", Type: Punctuation},
+			{Value: "not", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: ".", Type: Punctuation},
+			{Value: "x", Type: Attribute},
+			{Value: ")", Type: Punctuation},
+		}},
+		// Numbers, units, colours, keywords and !important
+		{"a{margin:-1.5em 0 auto;color:#FFF!important}", []Token{
+			{Value: "a", Type: Attribute},
+			{Value: "{", Type: Punctuation},
+			{Value: "margin", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: "-1.5", Type: Number},
+			{Value: "em", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "0", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "auto", Type: Literal},
+			{Value: ";", Type: Punctuation},
+			{Value: "color", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: "#FFF", Type: Number},
+			{Value: "!important", Type: Literal},
+			{Value: "}", Type: Punctuation},
+		}},
+		// Functions, strings and custom properties
+		{`a{--x:"y";width:calc(50% - var(--x));background:url(a.png)}`, []Token{
+			{Value: "a", Type: Attribute},
+			{Value: "{", Type: Punctuation},
+			{Value: "--x", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: `"`, Type: Punctuation},
+			{Value: "y", Type: String},
+			{Value: `"`, Type: Punctuation},
+			{Value: ";", Type: Punctuation},
+			{Value: "width", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: "calc", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: "50", Type: Number},
+			{Value: "%", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "-", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "var", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: "--x", Type: Attribute},
+			{Value: ")", Type: Punctuation},
+			{Value: ")", Type: Punctuation},
+			{Value: ";", Type: Punctuation},
+			{Value: "background", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: "url", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: "a.png", Type: String},
+			{Value: ")", Type: Punctuation},
+			{Value: "}", Type: Punctuation},
+		}},
+		// Nested at-rules and rules
+		{"@supports (display:grid) {@layer x{.a{&:hover{top:0}}}}", []Token{
+			{Value: "@supports", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: "(", Type: Punctuation},
+			{Value: "display", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: "grid", Type: Literal},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: "@layer", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: "x", Type: Literal},
+			{Value: "{", Type: Punctuation},
+			{Value: ".", Type: Punctuation},
+			{Value: "a", Type: Attribute},
+			{Value: "{", Type: Punctuation},
+			{Value: "&", Type: Punctuation},
+			{Value: ":", Type: Punctuation},
+			{Value: "hover", Type: Attribute},
+			{Value: "{", Type: Punctuation},
+			{Value: "top", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: "0", Type: Number},
+			{Value: "}", Type: Punctuation},
+			{Value: "}", Type: Punctuation},
+			{Value: "}", Type: Punctuation},
+			{Value: "}", Type: Punctuation},
+		}},
+		{"@container (width >= 40em) and (orientation: landscape)", []Token{
+			{Value: "@container", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: "(", Type: Punctuation},
+			{Value: "width", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: ">=", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "40", Type: Number},
+			{Value: "em", Type: Keyword},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "and", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "(", Type: Punctuation},
+			{Value: "orientation", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "landscape", Type: Literal},
+			{Value: ")", Type: Punctuation},
+		}},
+		// Comments, but not `//`
+		{"/* a\n * b */ c // d", []Token{
+			{Value: "/*", Type: Comment},
+			{Value: " a\n", Type: Comment},
+			{Value: " ", Type: Comment},
+			{Value: "*", Type: Comment},
+			{Value: " b ", Type: Comment},
+			{Value: "*/", Type: Comment},
+			{Value: " ", Type: Whitespace},
+			{Value: "c", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "// d", Type: Error},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.CSS, item.Subject),
+			item.Subject)
+	}
+}
@@ -0,0 +1,166 @@
+package lexers
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"sort"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// DelimitedLexer tokenizes delimiter-separated values using one of several
+// Lexers, chosen by whichever delimiter occurs most often outside of quotes
+// in the first line of input.
+type DelimitedLexer struct {
+	// Lexer is used unless another delimiter is more common than its own,
+	// and describes the DelimitedLexer, e.g. its Name and Filenames.
+	Lexer
+	// Delimiter is the delimiter of Lexer.
+	Delimiter byte
+	// Variants maps other delimiters to the Lexer used for them.
+	Variants map[byte]Lexer
+}
+
+func (d DelimitedLexer) Tokenize(r *bufio.Reader,
+	emit func(Token) error) error {
+	return d.detect(r).Tokenize(r, emit)
+}
+
+func (d DelimitedLexer) Format(r *bufio.Reader, emit func(Token) error) error {
+	return d.detect(r).Format(r, emit)
+}
+
+func (d DelimitedLexer) Minify(r *bufio.Reader, emit func(Token) error) error {
+	return d.detect(r).Minify(r, emit)
+}
+
+func (d DelimitedLexer) Validate(r *bufio.Reader,
+	emit func(Token) error) error {
+	return d.detect(r).Validate(r, emit)
+}
+
+// detect returns the Lexer for the delimiter used by the first line of
+// input, without consuming it.
+func (d DelimitedLexer) detect(r *bufio.Reader) Lexer {
+	// Peek until the first line has been buffered, or the buffer is full
+	var line []byte
+	for n := 64; ; n *= 2 {
+		if n > r.Size() {
+			n = r.Size()
+		}
+		b, err := r.Peek(n)
+		if i := bytes.IndexByte(b, '\n'); i >= 0 {
+			line = b[:i]
+			break
+		}
+		if err != nil || n == r.Size() {
+			line = b
+			break
+		}
+	}
+
+	counts := map[byte]int{}
+	quoted := false
+	for _, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if !quoted {
+			counts[c]++
+		}
+	}
+
+	// Consider the variants in a consistent order, so that ties are broken
+	// the same way each time
+	delimiters := make([]byte, 0, len(d.Variants))
+	for delimiter := range d.Variants {
+		delimiters = append(delimiters, delimiter)
+	}
+	sort.Slice(delimiters, func(i, j int) bool {
+		return delimiters[i] < delimiters[j]
+	})
+
+	lexer, most := d.Lexer, counts[d.Delimiter]
+	for _, delimiter := range delimiters {
+		if counts[delimiter] > most {
+			lexer, most = d.Variants[delimiter], counts[delimiter]
+		}
+	}
+	return lexer
+}
+
+// delimitedStates returns the states of a Lexer for values separated by the
+// given delimiter, quoted as described by RFC 4180. Quoted fields may span
+// several lines, and quotes are escaped by doubling them.
+func delimitedStates(delimiter byte) StatesSpec {
+	d := regexp.QuoteMeta(string(delimiter))
+	return StatesSpec{
+		"root": {
+			{Regexp: `\r?\n`, Type: Whitespace},
+			{Regexp: d, Type: Punctuation},
+			{Regexp: `"`, Type: String, State: "quoted"},
+			{Regexp: `[^"\r\n` + d + `][^\r\n` + d + `]*`, Type: Text},
+		},
+		"quoted": {
+			{Regexp: `""`, Type: Literal},
+			{Regexp: `"`, Type: String, State: "#pop"},
+			{Regexp: `[^"]+`, Type: String},
+		},
+	}
+}
+
+// delimitedColumnFilter annotates the tokens of each field with the
+// Column it belongs to.
+var delimitedColumnFilter = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		column := 1
+		return func(t Token) error {
+			if t.State == "root" && t.Type == Punctuation {
+				column++
+			} else if t.State == "root" && t.Type == Whitespace {
+				column = 1
+			} else if t != EndToken {
+				t.Column = column
+			}
+			return out(t)
+		}
+	})
+
+// CSV tokenizes comma-separated values. Values separated by semicolons or
+// vertical bars are also recognised, as determined by the first line.
+var CSV = DelimitedLexer{
+	Lexer: Lexer{
+		Name:      "csv",
+		MimeTypes: []string{"text/csv", "text/comma-separated-values"},
+		Filenames: []string{"*.csv"},
+		States:    delimitedStates(','),
+		Filters:   []Filter{delimitedColumnFilter},
+	},
+	Delimiter: ',',
+	Variants: map[byte]Lexer{
+		';': {
+			Name:    "csv",
+			States:  delimitedStates(';'),
+			Filters: []Filter{delimitedColumnFilter},
+		},
+		'|': {
+			Name:    "csv",
+			States:  delimitedStates('|'),
+			Filters: []Filter{delimitedColumnFilter},
+		},
+	},
+}
+
+// TSV tokenizes tab-separated values, which may be quoted as with CSV.
+var TSV = Lexer{
+	Name:      "tsv",
+	MimeTypes: []string{"text/tab-separated-values"},
+	Filenames: []string{"*.tsv", "*.tab"},
+	States:    delimitedStates('\t'),
+	Filters:   []Filter{delimitedColumnFilter},
+}
+
+func init() {
+	Register(CSV.Name, CSV)
+	Register(TSV.Name, TSV)
+}
@@ -0,0 +1,92 @@
+package lexers_test
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenizeColumns behaves as tokenize, but also returns the column of each
+// token.
+func tokenizeColumns(t *testing.T, tokenizer Tokenizer, s string) []Token {
+	tokens := []Token{}
+	err := tokenizer.Tokenize(bufio.NewReader(strings.NewReader(s)),
+		func(t Token) error {
+			if t != EndToken {
+				tokens = append(tokens, Token{Value: t.Value, Type: t.Type,
+					Column: t.Column})
+			}
+			return nil
+		})
+	assert.Equal(t, io.EOF, err, "tokenizer should return EOF")
+	return tokens
+}
+
+func TestLexerCSV(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "name", Type: Text, Column: 1},
+		{Value: ",", Type: Punctuation},
+		{Value: "quote", Type: Text, Column: 2},
+		{Value: "\r\n", Type: Whitespace},
+		{Value: "Fry", Type: Text, Column: 1},
+		{Value: ",", Type: Punctuation},
+		{Value: `"`, Type: String, Column: 2},
+		{Value: "I'm \n", Type: String, Column: 2},
+		{Value: `""`, Type: Literal, Column: 2},
+		{Value: "back", Type: String, Column: 2},
+		{Value: `""`, Type: Literal, Column: 2},
+		{Value: `"`, Type: String, Column: 2},
+		{Value: ",", Type: Punctuation},
+		{Value: "\n", Type: Whitespace},
+		{Value: ",", Type: Punctuation},
+		{Value: "x", Type: Text, Column: 2},
+	}, tokenizeColumns(t, lexers.CSV,
+		"name,quote\r\nFry,\"I'm \n\"\"back\"\"\",\n,x"))
+}
+
+func TestLexerCSVDelimiters(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"a;\"b;c\";1,5", []Token{
+			{Value: "a", Type: Text, Column: 1},
+			{Value: ";", Type: Punctuation},
+			{Value: `"`, Type: String, Column: 2},
+			{Value: "b;c", Type: String, Column: 2},
+			{Value: `"`, Type: String, Column: 2},
+			{Value: ";", Type: Punctuation},
+			{Value: "1,5", Type: Text, Column: 3},
+		}},
+		{"a|b,c|d", []Token{
+			{Value: "a", Type: Text, Column: 1},
+			{Value: "|", Type: Punctuation},
+			{Value: "b,c", Type: Text, Column: 2},
+			{Value: "|", Type: Punctuation},
+			{Value: "d", Type: Text, Column: 3},
+		}},
+		{"a;b,c", []Token{
+			{Value: "a;b", Type: Text, Column: 1},
+			{Value: ",", Type: Punctuation},
+			{Value: "c", Type: Text, Column: 2},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenizeColumns(t, lexers.CSV,
+			item.Subject), item.Subject)
+	}
+}
+
+func TestLexerTSV(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "a,b", Type: Text, Column: 1},
+		{Value: "\t", Type: Punctuation},
+		{Value: `"`, Type: String, Column: 2},
+		{Value: "c\td", Type: String, Column: 2},
+		{Value: `"`, Type: String, Column: 2},
+	}, tokenizeColumns(t, lexers.TSV, "a,b\t\"c\td\""))
+}
@@ -0,0 +1,326 @@
+package lexers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// Diff tokenizes unified and context diffs. Added and removed lines are
+// emitted as Inserted and Deleted, file names as Tag, and hunk headers as
+// Keyword. Lines within a hunk are tokenized in the "inserted", "deleted"
+// and "context" states, following their marker.
+//
+// Use DiffSyntaxFilter to highlight the content of each line according to
+// the file being patched.
+var Diff = Lexer{
+	Name:      "diff",
+	Aliases:   []string{"patch", "udiff"},
+	MimeTypes: []string{"text/x-diff", "text/x-patch"},
+	Filenames: []string{"*.diff", "*.patch"},
+	States: diffStates{StatesSpec{
+		"root": {
+			{Regexp: `\r?\n`, Type: Whitespace},
+			{Include: "headers"},
+			{Regexp: `[^\r\n]+`, Type: Text},
+		},
+		"headers": {
+			{Regexp: `diff [^\r\n]*`, Type: Keyword},
+			{Regexp: `(?:index|(?:new|deleted) file mode|old mode|new mode|` +
+				`similarity index|dissimilarity index|rename from|rename to|` +
+				`copy from|copy to|Index:|Only in|Binary files|` +
+				`GIT binary patch)\b[^\r\n]*`,
+				Type: Comment},
+			{Regexp: `={3,}`, Type: Punctuation},
+			// Unified hunks, e.g. `@@ -1,3 +1,4 @@ func main() {`, whose
+			// ranges are passed to the hunk state
+			{Regexp: `(@@ -[0-9]+(?:,[0-9]+)? \+[0-9]+(?:,[0-9]+)? @@)` +
+				`([ \t]*)([^\r\n]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Comment},
+				Capture:  1, State: "#reset hunk"},
+			// Context hunks, in which the old and new lines are listed in
+			// turn, each introduced by a range, e.g. `*** 1,3 ****`
+			{Regexp: `\*{15}`, Type: Punctuation, State: "#reset"},
+			{Regexp: `\*\*\* [0-9]+(?:,[0-9]+)? \*\*\*\*`, Type: Keyword,
+				State: "#reset oldHunk"},
+			{Regexp: `--- [0-9]+(?:,[0-9]+)? ----`, Type: Keyword,
+				State: "#reset newHunk"},
+			// File headers, e.g. `+++ b/main.go	2016-01-01 00:00:00`
+			{Regexp: `(---|\+\+\+|\*\*\*)( )([^\t\r\n]*)([^\r\n]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Tag, Comment},
+				State:    "#reset"},
+		},
+		// oldHunk and newHunk match the lines of a context diff hunk, in
+		// which `!` marks a line that was changed.
+		"oldHunk": {
+			{Regexp: `\r?\n`, Type: Whitespace},
+			{Regexp: `--- [0-9]+(?:,[0-9]+)? ----`, Type: Keyword,
+				State: "#reset newHunk"},
+			{Regexp: `[-!]`, Type: Deleted, State: "deleted"},
+			{Regexp: ` `, Type: Whitespace, State: "context"},
+			{Regexp: `\\[^\r\n]*`, Type: Comment},
+			{Regexp: ``, State: "#pop"},
+		},
+		"newHunk": {
+			{Regexp: `\r?\n`, Type: Whitespace},
+			{Regexp: `[+!]`, Type: Inserted, State: "inserted"},
+			{Regexp: ` `, Type: Whitespace, State: "context"},
+			{Regexp: `\\[^\r\n]*`, Type: Comment},
+			{Regexp: ``, State: "#pop"},
+		},
+		"inserted": {
+			{Regexp: `[^\r\n]+`, Type: Inserted},
+			{Regexp: ``, State: "#pop"},
+		},
+		"deleted": {
+			{Regexp: `[^\r\n]+`, Type: Deleted},
+			{Regexp: ``, State: "#pop"},
+		},
+		"context": {
+			{Regexp: `[^\r\n]+`, Type: Text},
+			{Regexp: ``, State: "#pop"},
+		},
+	}},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+// diffStates adds the `hunk` state to the states of the Diff lexer.
+type diffStates struct {
+	StatesSpec
+}
+
+func (s diffStates) Compile() (States, error) {
+	states, err := s.StatesSpec.Compile()
+	if err != nil {
+		return nil, err
+	}
+	return diffStateMap{states.(*StateMap)}, nil
+}
+
+// diffStateMap is the compiled form of diffStates.
+type diffStateMap struct {
+	*StateMap
+}
+
+// diffRange matches the ranges of a unified diff hunk header, e.g.
+// `-1,3 +1,4`, capturing the number of old and new lines, if given.
+var diffRange = regexp.MustCompile(
+	`-[0-9]+(?:,([0-9]+))? \+[0-9]+(?:,([0-9]+))?`)
+
+var (
+	diffNewline  = regexp.MustCompile(`\r?\n`)
+	diffInserted = regexp.MustCompile(`\+`)
+	diffDeleted  = regexp.MustCompile(`-`)
+	diffContext  = regexp.MustCompile(` `)
+	diffNoEOL    = regexp.MustCompile(`\\[^\r\n]*`)
+	diffEnd      = regexp.MustCompile(``)
+)
+
+// Get returns the named state. A name of the form `hunk:@@ -a,b +c,d @@`
+// refers to the lines of a unified diff hunk with b old and d new lines
+// remaining, which returns to the root state once both are exhausted, or
+// at the first line that doesn't belong to it.
+func (m diffStateMap) Get(name string) State {
+	if !strings.HasPrefix(name, "hunk:") {
+		return m.StateMap.Get(name)
+	}
+	counts := [2]int{1, 1}
+	if match := diffRange.FindStringSubmatch(name); match != nil {
+		for i, count := range match[1:] {
+			if count != "" {
+				counts[i], _ = strconv.Atoi(count)
+			}
+		}
+	}
+	oldCount, newCount := counts[0], counts[1]
+	next := func(oldCount, newCount int, state string) []string {
+		return []string{"#pop",
+			fmt.Sprintf("hunk:-0,%d +0,%d", oldCount, newCount), state}
+	}
+
+	state := State{RegexpRule{Regexp: diffNewline, Type: Whitespace}}
+	if newCount > 0 {
+		state = append(state, RegexpRule{Regexp: diffInserted, Type: Inserted,
+			NextStates: next(oldCount, newCount-1, "inserted")})
+	}
+	if oldCount > 0 {
+		state = append(state, RegexpRule{Regexp: diffDeleted, Type: Deleted,
+			NextStates: next(oldCount-1, newCount, "deleted")})
+	}
+	if oldCount > 0 && newCount > 0 {
+		state = append(state, RegexpRule{Regexp: diffContext, Type: Whitespace,
+			NextStates: next(oldCount-1, newCount-1, "context")})
+	}
+	return append(state,
+		RegexpRule{Regexp: diffNoEOL, Type: Comment},
+		RegexpRule{Regexp: diffEnd, NextStates: []string{"#pop"}})
+}
+
+// diffLine is a line within a hunk.
+type diffLine struct {
+	// marker is the token marking the line as inserted, deleted or context
+	marker Token
+	// content holds the tokens following the marker
+	content []Token
+	// trailer holds the tokens following the content, such as the line
+	// ending
+	trailer []Token
+}
+
+// side returns the side of the diff the line belongs to: -1 if it was
+// deleted, 1 if it was inserted, or 0 if it belongs to both.
+func (l *diffLine) side() int {
+	switch l.marker.Type {
+	case Deleted:
+		return -1
+	case Inserted:
+		return 1
+	}
+	return 0
+}
+
+// DiffSyntaxFilter tokenizes the content of the lines of each Diff hunk
+// using the Tokenizer for the file being patched, as found by
+// GetTokenizerForFilename. Markers remain Inserted or Deleted.
+//
+// The old and new sides of each hunk are tokenized separately, starting
+// from the root state of the Tokenizer. As a hunk may begin part way
+// through a construct the Tokenizer can't recognise in isolation, any line
+// whose content isn't tokenized cleanly is left as-is.
+var DiffSyntaxFilter = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		var tokenizer Tokenizer
+		var lines []*diffLine
+		// leading holds tokens preceding the first line of the hunk
+		var leading []Token
+
+		flush := func() error {
+			defer func() {
+				lines = nil
+				leading = nil
+			}()
+			if err := emitAll(out, leading...); err != nil {
+				return err
+			}
+			if tokenizer != nil {
+				highlightDiffSide(tokenizer, lines, -1)
+				highlightDiffSide(tokenizer, lines, 1)
+			}
+			for _, l := range lines {
+				if err := out(l.marker); err != nil {
+					return err
+				}
+				if err := emitAll(out, l.content...); err != nil {
+					return err
+				}
+				if err := emitAll(out, l.trailer...); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		return func(t Token) error {
+			switch t.State {
+			case "hunk", "oldHunk", "newHunk":
+				if t.Type == Inserted || t.Type == Deleted ||
+					(t.Type == Whitespace && t.Value == " ") {
+					lines = append(lines, &diffLine{marker: t})
+				} else if len(lines) > 0 {
+					l := lines[len(lines)-1]
+					l.trailer = append(l.trailer, t)
+				} else {
+					leading = append(leading, t)
+				}
+				return nil
+			case "inserted", "deleted", "context":
+				if len(lines) > 0 {
+					l := lines[len(lines)-1]
+					l.content = append(l.content, t)
+					return nil
+				}
+			}
+
+			if err := flush(); err != nil {
+				return err
+			}
+			if t.Type == Tag && t.State == "root" && t.Value != "/dev/null" {
+				// The last file header names the new file
+				name := path.Base(strings.TrimSpace(t.Value))
+				tokenizer, _ = GetTokenizerForFilename(name)
+			}
+			return out(t)
+		}
+	})
+
+// highlightDiffSide tokenizes the content of the lines on the given side of
+// a hunk as a single block, replacing the content of each line that is
+// tokenized without error. Context lines are taken from the new side.
+func highlightDiffSide(tokenizer Tokenizer, lines []*diffLine, side int) {
+	var b strings.Builder
+	for _, l := range lines {
+		if s := l.side(); s == side || s == 0 {
+			for _, t := range l.content {
+				b.WriteString(t.Value)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// Split the tokens into lines
+	tokenized := [][]Token{nil}
+	err := tokenizer.Tokenize(bufio.NewReader(strings.NewReader(b.String())),
+		func(t Token) error {
+			for t.Value != "" {
+				n := len(tokenized) - 1
+				i := strings.Index(t.Value, "\n")
+				if i < 0 {
+					tokenized[n] = append(tokenized[n], t)
+					break
+				}
+				if i > 0 {
+					tokenized[n] = append(tokenized[n], Token{
+						Value: t.Value[:i], Type: t.Type, State: t.State})
+				}
+				tokenized = append(tokenized, nil)
+				t.Value = t.Value[i+1:]
+			}
+			return nil
+		})
+	if err != nil && err != io.EOF {
+		return
+	}
+
+	i := 0
+	for _, l := range lines {
+		s := l.side()
+		if s != side && s != 0 {
+			continue
+		}
+		if i < len(tokenized) && (s == side || side == 1) &&
+			cleanDiffTokens(tokenized[i]) {
+			l.content = tokenized[i]
+		}
+		i++
+	}
+}
+
+// cleanDiffTokens returns true if none of the given tokens are errors.
+func cleanDiffTokens(tokens []Token) bool {
+	for _, t := range tokens {
+		if t.Type == Error {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	Register(Diff.Name, Diff)
+}
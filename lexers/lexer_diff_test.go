@@ -0,0 +1,153 @@
+package lexers_test
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDiff = `--- a/fry.json
++++ b/fry.json	2016-01-01
+@@ -1 +1,2 @@ x
+-[1]
++[2,
++ 3]
+\ No newline at end of file
+`
+
+func TestLexerDiff(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "---", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "a/fry.json", Type: Tag},
+		{Value: "\n", Type: Whitespace},
+		{Value: "+++", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "b/fry.json", Type: Tag},
+		{Value: "\t2016-01-01", Type: Comment},
+		{Value: "\n", Type: Whitespace},
+		{Value: "@@ -1 +1,2 @@", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "x", Type: Comment},
+		{Value: "\n", Type: Whitespace},
+		{Value: "-", Type: Deleted},
+		{Value: "[1]", Type: Deleted},
+		{Value: "\n", Type: Whitespace},
+		{Value: "+", Type: Inserted},
+		{Value: "[2,", Type: Inserted},
+		{Value: "\n", Type: Whitespace},
+		{Value: "+", Type: Inserted},
+		{Value: " 3]", Type: Inserted},
+		{Value: "\n", Type: Whitespace},
+		{Value: `\ No newline at end of file`, Type: Comment},
+		{Value: "\n", Type: Whitespace},
+	}, tokenize(t, lexers.Diff, testDiff))
+
+	assert.Equal(t, []Token{
+		{Value: "*** 1,2 ****", Type: Keyword},
+		{Value: "\n", Type: Whitespace},
+		{Value: "!", Type: Deleted},
+		{Value: " a", Type: Deleted},
+		{Value: "\n", Type: Whitespace},
+		{Value: "--- 1,2 ----", Type: Keyword},
+		{Value: "\n", Type: Whitespace},
+		{Value: "!", Type: Inserted},
+		{Value: " b", Type: Inserted},
+		{Value: "\n", Type: Whitespace},
+		{Value: " ", Type: Whitespace},
+		{Value: " c", Type: Text},
+	}, tokenize(t, lexers.Diff, "*** 1,2 ****\n! a\n--- 1,2 ----\n! b\n  c"))
+}
+
+func TestDiffSyntaxFilter(t *testing.T) {
+	tokens := []Token{}
+	err := lexers.Diff.Tokenize(bufio.NewReader(strings.NewReader(testDiff)),
+		lexers.DiffSyntaxFilter.Filter(func(t Token) error {
+			if t != EndToken {
+				tokens = append(tokens, Token{Value: t.Value, Type: t.Type})
+			}
+			return nil
+		}))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []Token{
+		{Value: "-", Type: Deleted},
+		{Value: "[", Type: Punctuation},
+		{Value: "1", Type: Number},
+		{Value: "]", Type: Punctuation},
+		{Value: "\n", Type: Whitespace},
+		{Value: "+", Type: Inserted},
+		{Value: "[", Type: Punctuation},
+		{Value: "2", Type: Number},
+		{Value: ",", Type: Punctuation},
+		{Value: "\n", Type: Whitespace},
+		{Value: "+", Type: Inserted},
+		{Value: " ", Type: Whitespace},
+		{Value: "3", Type: Number},
+		{Value: "]", Type: Punctuation},
+		{Value: "\n", Type: Whitespace},
+		{Value: `\ No newline at end of file`, Type: Comment},
+		{Value: "\n", Type: Whitespace},
+	}, tokens[13:])
+}
+
+const testMultiDiff = `--- a/fry.json
++++ b/fry.json
+@@ -1 +1 @@
+-[1]
++[2]
+--- a/fry.go
++++ b/fry.go
+@@ -1 +1 @@
+-var a
++var b
+`
+
+func TestLexerDiffMultipleFiles(t *testing.T) {
+	tokens := tokenize(t, lexers.Diff, testMultiDiff)
+	assert.Equal(t, []Token{
+		{Value: "+", Type: Inserted},
+		{Value: "[2]", Type: Inserted},
+		{Value: "\n", Type: Whitespace},
+		{Value: "---", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "a/fry.go", Type: Tag},
+		{Value: "\n", Type: Whitespace},
+	}, tokens[13:20])
+
+	// The second file is highlighted according to its own name
+	tokens = []Token{}
+	err := lexers.Diff.Tokenize(
+		bufio.NewReader(strings.NewReader(testMultiDiff)),
+		lexers.DiffSyntaxFilter.Filter(func(t Token) error {
+			if t != EndToken {
+				tokens = append(tokens, Token{Value: t.Value, Type: t.Type})
+			}
+			return nil
+		}))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []Token{
+		{Value: "+", Type: Inserted},
+		{Value: "var", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "b", Type: Text},
+		{Value: "\n", Type: Whitespace},
+	}, tokens[len(tokens)-5:])
+}
+
+func TestDiffSyntaxFilterStrayLine(t *testing.T) {
+	// Line content outside of a hunk is passed through as-is
+	tokens := []Token{}
+	emit := lexers.DiffSyntaxFilter.Filter(func(t Token) error {
+		tokens = append(tokens, t)
+		return nil
+	})
+	stray := Token{Value: "x", Type: Inserted, State: "inserted"}
+	assert.Nil(t, emit(stray))
+	assert.Nil(t, emit(EndToken))
+	assert.Equal(t, []Token{stray, EndToken}, tokens)
+}
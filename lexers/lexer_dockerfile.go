@@ -0,0 +1,180 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// dockerfileVariable matches a reference to a variable declared by `ARG` or
+// `ENV`, e.g. `$VERSION` or `${VERSION:-latest}`.
+const dockerfileVariable = `\$\{[^}\r\n]*\}|\$[A-Za-z_]\w*`
+
+// dockerfileDelimiter matches the delimiter of a here-document, e.g. `EOF`
+// or `"eof"`, following its operator, capturing it without quotes as the
+// third group of the rule.
+const dockerfileDelimiter = `(["']?)([\w.-]+)(["']?)`
+
+// dockerfileString matches a JSON string within the exec form of a command.
+const dockerfileString = `"(?:[^"\\\r\n]|\\.)*"`
+
+// Dockerfile matches Dockerfiles, as built by Docker and other OCI builders.
+// The shell form of `RUN`, `CMD` and `ENTRYPOINT`, including the body of
+// any here-documents, is highlighted by the "shell" Tokenizer, if
+// registered, and the exec form by the JSON lexer.
+var Dockerfile = Lexer{
+	Name:      "dockerfile",
+	Aliases:   []string{"docker", "containerfile"},
+	MimeTypes: []string{"text/x-dockerfile"},
+	Filenames: []string{"Dockerfile", "Dockerfile.*", "*.Dockerfile",
+		"*.dockerfile", "Containerfile", "Containerfile.*"},
+	States: StatesSpec{
+		// root matches any parser directives, which may only appear before
+		// anything else in the file.
+		"root": {
+			{Regexp: `(#)([ \t]*)([A-Za-z]+)([ \t]*)(=)([ \t]*)([^\r\n]*)` +
+				`(\r?\n)?`,
+				SubTypes: []TokenType{Comment, Whitespace, Keyword, Whitespace,
+					Assignment, Whitespace, String, Whitespace}},
+			{Regexp: ``, State: "body"},
+		},
+		"body": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			{Regexp: `(?i)((?:RUN|CMD|ENTRYPOINT|SHELL)\b)([ \t]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace},
+				State:    "command flags"},
+			{Regexp: `(?i)(HEALTHCHECK\b)([ \t]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace},
+				State:    "healthcheck"},
+			{Regexp: `(?i)((?:ENV|ARG|LABEL)\b)([ \t]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace},
+				State:    "assignments"},
+			// ONBUILD is followed by another instruction
+			{Regexp: `(?i)(ONBUILD\b)([ \t]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace}},
+			{Regexp: `(?i)((?:FROM|ADD|COPY|EXPOSE|VOLUME|USER|WORKDIR|` +
+				`STOPSIGNAL|MAINTAINER)\b)([ \t]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace},
+				State:    "arguments"},
+			{Regexp: `[^\r\n]+`, Type: Text},
+		},
+		// flags matches any options preceding the arguments of an
+		// instruction, e.g. `--mount=type=cache,target=/root/.cache`
+		"flags": {
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `--[A-Za-z][\w-]*(?:=[^\s]*)?`, Type: Attribute},
+			{Regexp: ``, State: "#pop"},
+		},
+		// command matches the arguments of `RUN`, `CMD`, `ENTRYPOINT` and
+		// `SHELL`, which take the exec form if they are a JSON array of
+		// strings, e.g. `["app", "-v"]`, or the shell form otherwise. A
+		// single-line exec form is emitted as a String to be delegated.
+		"command": {
+			{Regexp: `(\[[ \t]*(?:` + dockerfileString + `(?:[ \t]*,[ \t]*` +
+				dockerfileString + `)*[ \t]*)?\])([ \t]*)(\r?\n|$)`,
+				SubTypes: []TokenType{String, Whitespace, Whitespace},
+				State:    "#pop"},
+			// Exec form continued onto the next line
+			{Regexp: `(\[[ \t]*(?:` + dockerfileString + `[ \t]*,[ \t]*)*` +
+				`(?:` + dockerfileString + `[ \t]*)?)(\\)(\r?\n)`,
+				SubTypes: []TokenType{String, Punctuation, Whitespace},
+				State:    "#pop execForm"},
+			{Regexp: ``, State: "#pop shellForm"},
+		},
+		// execForm matches the lines following the first line of an exec
+		// form continued with a backslash.
+		"execForm": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `(\\)(\r?\n)`, SubTypes: []TokenType{Punctuation,
+				Whitespace}},
+			{Regexp: `[^\r\n\\]+|\\`, Type: String},
+		},
+		// shellForm matches a command, along with the operators of any
+		// here-documents, as Text to be delegated.
+		"shellForm": {
+			{Regexp: `\r?\n`, Type: Text, State: "#pop"},
+			{Regexp: `(<<-)` + dockerfileDelimiter, Type: Text, Capture: 3,
+				State: "#pop indentedShellHeredoc shellForm"},
+			{Regexp: `(<<)` + dockerfileDelimiter, Type: Text, Capture: 3,
+				State: "#pop shellHeredoc shellForm"},
+			{Regexp: `[^\r\n\\<]+|\\\r?\n|\\[^\r\n]|<|\\`, Type: Text},
+		},
+		// shellHeredoc matches the body of a here-document following `RUN`,
+		// which the shell receives along with the command.
+		"shellHeredoc":         heredocBody(false, Text, Text),
+		"indentedShellHeredoc": heredocBody(true, Text, Text),
+		"healthcheck": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `--[A-Za-z][\w-]*(?:=[^\s]*)?`, Type: Attribute},
+			{Regexp: `(?i)(CMD\b)([ \t]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace},
+				State:    "#pop command flags"},
+			{Regexp: `(?i)NONE\b`, Type: Keyword},
+			{Regexp: `[^\s]+`, Type: Text},
+		},
+		// arguments matches the arguments of all other instructions,
+		// including here-documents following `COPY` or `ADD`.
+		"arguments": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `\\\r?\n`, Type: Punctuation},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `--[A-Za-z][\w-]*(?:=[^\s]*)?`, Type: Attribute},
+			{Regexp: `(?i)AS\b`, Type: Keyword},
+			{Regexp: `(<<-)` + dockerfileDelimiter, Type: Punctuation,
+				Capture: 3, State: "#pop indentedFileHeredoc arguments"},
+			{Regexp: `(<<)` + dockerfileDelimiter, Type: Punctuation,
+				Capture: 3, State: "#pop fileHeredoc arguments"},
+			{Regexp: dockerfileVariable, Type: Attribute},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"`, Type: String},
+			{Regexp: `[\[\],]`, Type: Punctuation},
+			{Regexp: `[^\s$\\"\[\],]+|[$\\"]`, Type: Text},
+		},
+		// fileHeredoc matches the body of a here-document following `COPY`
+		// or `ADD`, i.e. the contents of a file.
+		"fileHeredoc":         heredocBody(false, String, Punctuation),
+		"indentedFileHeredoc": heredocBody(true, String, Punctuation),
+		// assignments matches the `key=value` pairs following `ENV`, `ARG`
+		// and `LABEL`.
+		"assignments": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `\\\r?\n`, Type: Punctuation},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `([^\s=]+)(=)`, SubTypes: []TokenType{Attribute,
+				Assignment}, State: "value"},
+			// Legacy form, e.g. `ENV VERSION 1.0`
+			{Regexp: `([^\s=]+)([ \t]+)([^\r\n]+)`,
+				SubTypes: []TokenType{Attribute, Whitespace, String}},
+			{Regexp: `[^\s=]+`, Type: Attribute},
+		},
+		"value": {
+			{Regexp: dockerfileVariable, Type: Attribute},
+			{Regexp: `"`, Type: String, State: "doubleQuoted"},
+			{Regexp: `'[^'\r\n]*'`, Type: String},
+			{Regexp: `(?:[^\s$\\"']|\\[^\r\n])+|\$`, Type: String},
+			{Regexp: ``, State: "#pop"},
+		},
+		"doubleQuoted": {
+			{Regexp: `"`, Type: String, State: "#pop"},
+			{Regexp: dockerfileVariable, Type: Attribute},
+			{Regexp: `(?:[^"\\$]|\\[\s\S])+|\$`, Type: String},
+		},
+	},
+	Filters: []Filter{
+		RemoveEmptiesFilter,
+		DelegateFilter{
+			States: []string{"shellForm", "shellHeredoc",
+				"indentedShellHeredoc"},
+			Types: []TokenType{Text, Whitespace},
+			Select: func(Token, Tokenizer) Tokenizer {
+				return GetTokenizer("shell")
+			},
+		},
+		DelegateFilter{
+			States: []string{"command"},
+			Types:  []TokenType{String},
+			Select: func(Token, Tokenizer) Tokenizer { return JSON },
+		},
+	},
+}
+
+func init() {
+	Register(Dockerfile.Name, Dockerfile)
+}
@@ -0,0 +1,134 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerDockerfile(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"# syntax=docker/dockerfile:1\nFROM go:${V:-1} AS build", []Token{
+			{Value: "#", Type: Comment},
+			{Value: " ", Type: Whitespace},
+			{Value: "syntax", Type: Keyword},
+			{Value: "=", Type: Assignment},
+			{Value: "docker/dockerfile:1", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "FROM", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "go:", Type: Text},
+			{Value: "${V:-1}", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "AS", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "build", Type: Text},
+		}},
+		{`ENV NAME="Fry $LAST" AGE=1000`, []Token{
+			{Value: "ENV", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "NAME", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: `"`, Type: String},
+			{Value: "Fry ", Type: String},
+			{Value: "$LAST", Type: Attribute},
+			{Value: `"`, Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "AGE", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: "1000", Type: String},
+		}},
+		{"RUN --mount=type=cache echo $HOME", []Token{
+			{Value: "RUN", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "--mount=type=cache", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "echo", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "$HOME", Type: Attribute},
+		}},
+		{"RUN <<EOF\necho\nEOF\nCMD [\"app\"]", []Token{
+			{Value: "RUN", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "<<", Type: Operator},
+			{Value: "EOF", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "echo", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "EOF", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "CMD", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "[", Type: Punctuation},
+			{Value: `"`, Type: Punctuation},
+			{Value: "app", Type: String},
+			{Value: `"`, Type: Punctuation},
+			{Value: "]", Type: Punctuation},
+		}},
+		{"COPY <<EOF /etc/fry\nname=Fry\nEOF", []Token{
+			{Value: "COPY", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "<<EOF", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "/etc/fry", Type: Text},
+			{Value: "\n", Type: Whitespace},
+			{Value: "name=Fry", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "EOF", Type: Punctuation},
+		}},
+		{"RUN <<eof\nDONE\neof\nUSER me", []Token{
+			{Value: "RUN", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "<<", Type: Operator},
+			{Value: "eof", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "DONE", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "eof", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "USER", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "me", Type: Text},
+		}},
+		{"RUN [ -f x ] && echo", []Token{
+			{Value: "RUN", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "[", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "-f", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "x", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "]", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "&&", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "echo", Type: Attribute},
+		}},
+		{"CMD [\"a\", \\\n  \"b\"]\nUSER me", []Token{
+			{Value: "CMD", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "[", Type: Punctuation},
+			{Value: `"`, Type: Punctuation},
+			{Value: "a", Type: String},
+			{Value: `"`, Type: Punctuation},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: `\`, Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: `  "b"]`, Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "USER", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "me", Type: Text},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.Dockerfile,
+			item.Subject), item.Subject)
+	}
+}
@@ -0,0 +1,58 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+var Dotenv = Lexer{
+	Name:      "dotenv",
+	Aliases:   []string{"env"},
+	MimeTypes: []string{"text/x-dotenv", "application/x-dotenv"},
+	Filenames: []string{".env", ".env.*", "*.env"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			{Regexp: `export\b`, Type: Keyword},
+			{Regexp: `([A-Za-z_][\w.-]*)([ \t]*)(=)([ \t]*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "value"},
+		},
+		"value": {
+			{Regexp: `'`, Type: String, State: "#pop singleQuoted"},
+			{Regexp: `"`, Type: String, State: "#pop doubleQuoted"},
+			{Regexp: ``, State: "#pop unquoted"},
+		},
+		// interpolation matches references to other variables, e.g. `$HOME`
+		// or `${PORT:-8080}`
+		"interpolation": {
+			{Regexp: `\$\{[^}\r\n]*\}|\$[A-Za-z_]\w*`, Type: Attribute},
+		},
+		"unquoted": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `[ \t]+#[^\r\n]*`, Type: Comment},
+			{Include: "interpolation"},
+			{Regexp: `\\[^\r\n]`, Type: Literal},
+			{Regexp: `[^\s$\\]+(?:[ \t]+[^\s#$\\][^\s$\\]*)*|[ \t]+|[$\\]`,
+				Type: String},
+		},
+		// doubleQuoted matches a double-quoted value, which may span several
+		// lines
+		"doubleQuoted": {
+			{Regexp: `"`, Type: String, State: "#pop"},
+			{Regexp: `\\[\s\S]`, Type: Literal},
+			{Include: "interpolation"},
+			{Regexp: `[^"\\$]+|\$`, Type: String},
+		},
+		// singleQuoted matches a single-quoted value, within which no
+		// escapes or interpolation take place
+		"singleQuoted": {
+			{Regexp: `'`, Type: String, State: "#pop"},
+			{Regexp: `[^']+`, Type: String},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(Dotenv.Name, Dotenv)
+}
@@ -0,0 +1,40 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerDotenv(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "export", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "NAME", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "Fry", Type: String},
+		{Value: " # c", Type: Comment},
+		{Value: "\n", Type: Whitespace},
+		{Value: "GREETING", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: `"`, Type: String},
+		{Value: "Hi ", Type: String},
+		{Value: "${NAME}", Type: Attribute},
+		{Value: `\n`, Type: Literal},
+		{Value: `"`, Type: String},
+		{Value: "\n", Type: Whitespace},
+		{Value: "RAW", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "'", Type: String},
+		{Value: "$NAME", Type: String},
+		{Value: "'", Type: String},
+		{Value: "\n", Type: Whitespace},
+		{Value: "HOME_DIR", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "$HOME", Type: Attribute},
+		{Value: "/fry", Type: String},
+	}, tokenize(t, lexers.Dotenv, "export NAME=Fry # c\n"+
+		"GREETING=\"Hi ${NAME}\\n\"\nRAW='$NAME'\nHOME_DIR=$HOME/fry"))
+}
@@ -0,0 +1,79 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+const (
+	// goDigits and goHexDigits match digits with optional `_` separators
+	goDigits    = `[0-9](?:_?[0-9])*`
+	goHexDigits = `[0-9a-fA-F](?:_?[0-9a-fA-F])*`
+)
+
+var Go = Lexer{
+	Name:      "go",
+	Aliases:   []string{"golang"},
+	MimeTypes: []string{"text/x-go", "application/x-go"},
+	Filenames: []string{"*.go"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\s+`, Type: Whitespace},
+			// Compiler directives, e.g. `//go:build linux`
+			{Regexp: `(//)(go:[a-z]+|line )([^\r\n]*)`,
+				SubTypes: []TokenType{Comment, Tag, Comment}},
+			{Regexp: `//[^\r\n]*`, Type: Comment},
+			{Regexp: `/\*`, Type: Comment, State: "comment"},
+			{Regexp: "`", Type: String, State: "rawString"},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"`, Type: String},
+			{Regexp: `'(?:[^'\\\r\n]|\\(?:[abfnrtv\\'"]|[0-7]{3}|` +
+				`x[0-9a-fA-F]{2}|u[0-9a-fA-F]{4}|U[0-9a-fA-F]{8}))'`,
+				Type: String},
+			{Include: "number"},
+			// Field and method selectors, e.g. `.String`
+			{Regexp: `(\.)([\pL_][\pL\pN_]*)`,
+				SubTypes: []TokenType{Punctuation, Attribute}},
+			{Regexp: `(?:break|case|chan|const|continue|default|defer|else|` +
+				`fallthrough|for|func|go|goto|if|import|interface|map|` +
+				`package|range|return|select|struct|switch|type|var)\b`,
+				Type: Keyword},
+			{Regexp: `(?:true|false|iota|nil)\b`, Type: Literal},
+			{Regexp: `(?:any|bool|byte|comparable|complex64|complex128|` +
+				`error|float32|float64|int|int8|int16|int32|int64|rune|` +
+				`string|uint|uint8|uint16|uint32|uint64|uintptr)\b`,
+				Type: Keyword},
+			{Regexp: `(?:append|cap|clear|close|complex|copy|delete|imag|` +
+				`len|make|max|min|new|panic|print|println|real|recover)\b`,
+				Type: Attribute},
+			{Regexp: `[\pL_][\pL\pN_]*`, Type: Text},
+			{Regexp: `:?=`, Type: Assignment},
+			{Regexp: `<<=|>>=|&\^=|\.\.\.|&&|\|\||<-|\+\+|--|==|!=|<=|>=|` +
+				`<<|>>|&\^|[-+*/%&|^]=|[-+*/%&|^<>!~]`,
+				Type: Operator},
+			{Regexp: `[()\[\]{},;.:]`, Type: Punctuation},
+		},
+		// number matches integer, floating-point and imaginary literals
+		"number": {
+			{Regexp: `(?:0[xX](?:_?` + goHexDigits + `(?:\.(?:` + goHexDigits +
+				`)?)?|\.` + goHexDigits + `)(?:[pP][-+]?` + goDigits + `)?|` +
+				`0[bB](?:_?[01])+|0[oO](?:_?[0-7])+|` +
+				`(?:` + goDigits + `(?:\.(?:` + goDigits + `)?)?|\.` + goDigits +
+				`)(?:[eE][-+]?` + goDigits + `)?)i?`,
+				Type: Number},
+		},
+		"comment": {
+			{Regexp: `\*/`, Type: Comment, State: "#pop"},
+			{Regexp: `[^*]+|\*`, Type: Comment},
+		},
+		// rawString matches the contents of a raw string, highlighting the
+		// keys and values of struct tags, e.g. `json:"name,omitempty"`
+		"rawString": {
+			{Regexp: "`", Type: String, State: "#pop"},
+			{Regexp: `([\w.-]+)(:)("(?:[^"\\` + "`" + `]|\\.)*")`,
+				SubTypes: []TokenType{Attribute, Punctuation, String}},
+			{Regexp: "[^`\\w.-]+|[\\w.-]+", Type: String},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(Go.Name, Go)
+}
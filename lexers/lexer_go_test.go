@@ -0,0 +1,167 @@
+package lexers_test
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerGo(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"0x1p-2 0X_Ff 0o17 017 0b1_0 1_000.5e+3i .5 1. 3i", []Token{
+			{Value: "0x1p-2", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "0X_Ff", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "0o17", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "017", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "0b1_0", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "1_000.5e+3i", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: ".5", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "1.", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "3i", Type: Number},
+		}},
+		{`s := "a\"b" + 'c' + '\n' + ` + "`raw\\`", []Token{
+			{Value: "s", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: ":=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: `"a\"b"`, Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "+", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "'c'", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "+", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: `'\n'`, Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "+", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "`", Type: String},
+			{Value: "raw", Type: String},
+			{Value: `\`, Type: String},
+			{Value: "`", Type: String},
+		}},
+		{"func F[T ~int | any](x T) error { return nil }", []Token{
+			{Value: "func", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "F", Type: Text},
+			{Value: "[", Type: Punctuation},
+			{Value: "T", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "~", Type: Operator},
+			{Value: "int", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "any", Type: Keyword},
+			{Value: "]", Type: Punctuation},
+			{Value: "(", Type: Punctuation},
+			{Value: "x", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "T", Type: Text},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "error", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "return", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "nil", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: "}", Type: Punctuation},
+		}},
+		{"Name string `json:\"name,omitempty\" db:\"n\"`", []Token{
+			{Value: "Name", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "string", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "`", Type: String},
+			{Value: "json", Type: Attribute},
+			{Value: ":", Type: Punctuation},
+			{Value: `"name,omitempty"`, Type: String},
+			{Value: " ", Type: String},
+			{Value: "db", Type: Attribute},
+			{Value: ":", Type: Punctuation},
+			{Value: `"n"`, Type: String},
+			{Value: "`", Type: String},
+		}},
+		{"//go:build linux\n// x\nv.len = len(v) /* y */", []Token{
+			{Value: "//", Type: Comment},
+			{Value: "go:build", Type: Tag},
+			{Value: " linux", Type: Comment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "// x", Type: Comment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "v", Type: Text},
+			{Value: ".", Type: Punctuation},
+			{Value: "len", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "len", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: "v", Type: Text},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "/*", Type: Comment},
+			{Value: " y ", Type: Comment},
+			{Value: "*/", Type: Comment},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.Go, item.Subject),
+			item.Subject)
+	}
+}
+
+// TestLexerGoSelfHosting tokenizes the Go sources of this repository, which
+// should not produce any errors.
+func TestLexerGoSelfHosting(t *testing.T) {
+	files := 0
+	err := filepath.Walk("..", func(path string, info os.FileInfo,
+		err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+		files++
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		line := 1
+		err = lexers.Go.Tokenize(bufio.NewReader(f), func(tok Token) error {
+			if tok.Type == Error {
+				t.Errorf("%s:%d: unexpected %q", path, line, tok.Value)
+			}
+			line += strings.Count(tok.Value, "\n")
+			return nil
+		})
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	})
+	assert.Nil(t, err)
+	assert.NotEqual(t, 0, files, "should find source files")
+}
@@ -0,0 +1,191 @@
+package lexers
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// goTemplateStates are the states of the Go template lexers.
+var goTemplateStates = StatesSpec{
+	"root": {
+		// Comments, e.g. `{{/* a comment */}}` or `{{- /* x */ -}}`
+		{Regexp: `\{\{(?:- )?/\*`, Type: Comment, State: "comment"},
+		{Regexp: `\{\{-?`, Type: Punctuation, State: "action"},
+		{Regexp: `[^{]+|\{`, Type: Text},
+	},
+	"comment": {
+		{Regexp: `\*/(?: -)?\}\}`, Type: Comment, State: "#pop"},
+		{Regexp: `[^*]+|\*`, Type: Comment},
+	},
+	// action matches the pipelines and control structures within
+	// `{{ }}`, with optional trim markers, e.g. `{{- .Name -}}`
+	"action": {
+		{Regexp: `-?\}\}`, Type: Punctuation, State: "#pop"},
+		{Regexp: `\s+`, Type: Whitespace},
+		{Regexp: `"(?:[^"\\\r\n]|\\.)*"|'(?:[^'\\\r\n]|\\.)+'`,
+			Type: String},
+		{Regexp: "`[^`]*`", Type: String},
+		{Regexp: `(?:if|else|end|range|with|define|template|block|` +
+			`break|continue)\b`,
+			Type: Keyword},
+		{Regexp: `(?:true|false|nil)\b`, Type: Literal},
+		{Regexp: `(?:and|call|html|index|slice|js|len|not|or|print|` +
+			`printf|println|urlquery|eq|ne|lt|le|gt|ge)\b`,
+			Type: Attribute},
+		// Variables, e.g. `$` or `$item`
+		{Regexp: `\$[\pL\pN_]*`, Type: Tag},
+		// Fields and methods, e.g. `.Site.Title`
+		{Regexp: `(?:\.[\pL_][\pL\pN_]*)+`, Type: Attribute},
+		{Regexp: `[-+]?(?:0[xX][0-9a-fA-F_]+|[0-9][0-9_]*` +
+			`(?:\.[0-9_]*)?(?:[eE][-+]?[0-9]+)?)i?`,
+			Type: Number},
+		{Regexp: `[\pL_][\pL\pN_]*`, Type: Text},
+		{Regexp: `:?=`, Type: Assignment},
+		{Regexp: `\|`, Type: Operator},
+		{Regexp: `[(),.]`, Type: Punctuation},
+	},
+}
+
+// NewGoTemplate returns a Lexer for Go text/template and html/template
+// templates, in which the text surrounding each action is tokenized by
+// host, e.g. HTML. If host is nil, the text is emitted as Text.
+func NewGoTemplate(host Tokenizer) Lexer {
+	lexer := Lexer{
+		Name:      "gotemplate",
+		Aliases:   []string{"gotmpl", "go-template", "tmpl"},
+		MimeTypes: []string{"text/x-go-template"},
+		Filenames: []string{"*.tmpl", "*.gotmpl"},
+		States:    goTemplateStates,
+		Filters:   []Filter{RemoveEmptiesFilter},
+	}
+	if host != nil {
+		lexer.Filters = append(lexer.Filters,
+			templateHostFilter(host, "root"))
+	}
+	return lexer
+}
+
+var (
+	// GoTemplate tokenizes Go templates, leaving the text surrounding each
+	// action as Text.
+	GoTemplate = NewGoTemplate(nil)
+	// GoTemplateHTML tokenizes Go templates producing HTML.
+	GoTemplateHTML = Lexer{
+		Name:    "gotemplate-html",
+		Aliases: []string{"gotmpl-html", "html+gotemplate"},
+		States:  goTemplateStates,
+		Filters: []Filter{RemoveEmptiesFilter,
+			templateHostFilter(HTML, "root")},
+	}
+	// GoTemplateYAML tokenizes Go templates producing YAML, such as Helm
+	// charts.
+	GoTemplateYAML = Lexer{
+		Name:    "gotemplate-yaml",
+		Aliases: []string{"gotmpl-yaml", "yaml+gotemplate", "helm"},
+		States:  goTemplateStates,
+		Filters: []Filter{RemoveEmptiesFilter,
+			templateHostFilter(YAML, "root")},
+	}
+)
+
+// templateHostFilter tokenizes the Text of the given states of a template
+// using host, as if the tags of the template were absent, so that
+// constructs spanning a tag, such as an HTML attribute value, are tokenized
+// correctly. The tokens of the template's tags are emitted between those
+// of the host, splitting them if necessary. Should host fail part way
+// through, the remainder of the text is emitted as-is.
+//
+// As the host must see the entire text, all tokens are buffered until the
+// end of input.
+func templateHostFilter(host Tokenizer, states ...string) Filter {
+	return FilterFunc(func(out func(Token) error) func(Token) error {
+		var text strings.Builder
+		// tags holds the tokens of the template's tags, along with their
+		// offset within the text
+		var tags []Token
+		var offsets []int
+
+		return func(t Token) error {
+			if t != EndToken {
+				for _, state := range states {
+					if t.State == state && t.Type == Text {
+						text.WriteString(t.Value)
+						return nil
+					}
+				}
+				tags = append(tags, t)
+				offsets = append(offsets, text.Len())
+				return nil
+			}
+
+			// emitTags emits the tags preceding the given offset
+			emitTags := func(offset int) error {
+				for len(tags) > 0 && offsets[0] <= offset {
+					if err := out(tags[0]); err != nil {
+						return err
+					}
+					tags, offsets = tags[1:], offsets[1:]
+				}
+				return nil
+			}
+
+			offset := 0
+			// emitText emits a token of the text, split at each tag within
+			// it
+			emitText := func(h Token) error {
+				for h.Value != "" {
+					if err := emitTags(offset); err != nil {
+						return err
+					}
+					// Split the token at the next tag within it
+					n := len(h.Value)
+					if len(offsets) > 0 && offsets[0] < offset+n {
+						n = offsets[0] - offset
+					}
+					part := h
+					part.Value = h.Value[:n]
+					if err := out(part); err != nil {
+						return err
+					}
+					h.Value = h.Value[n:]
+					offset += n
+				}
+				return nil
+			}
+
+			// failed holds any error returned by out
+			var failed error
+			r := bufio.NewReader(strings.NewReader(text.String()))
+			err := host.Tokenize(r, func(h Token) error {
+				if h == EndToken {
+					return nil
+				}
+				failed = emitText(h)
+				return failed
+			})
+			if failed != nil {
+				return failed
+			} else if err != nil && err != io.EOF {
+				// The host failed; emit the rest of the text as-is
+				rest := Token{Value: text.String()[offset:], Type: Text,
+					State: states[0]}
+				if err := emitText(rest); err != nil {
+					return err
+				}
+			}
+			if err := emitTags(text.Len()); err != nil {
+				return err
+			}
+			return out(t)
+		}
+	})
+}
+
+func init() {
+	Register(GoTemplate.Name, GoTemplate)
+	Register(GoTemplateHTML.Name, GoTemplateHTML)
+	Register(GoTemplateYAML.Name, GoTemplateYAML)
+}
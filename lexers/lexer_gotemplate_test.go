@@ -0,0 +1,129 @@
+package lexers_test
+
+import (
+	"bufio"
+	"errors"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerGoTemplate(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"Hi {{- .User.Name -}}!", []Token{
+			{Value: "Hi ", Type: Text},
+			{Value: "{{-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: ".User.Name", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "-}}", Type: Punctuation},
+			{Value: "!", Type: Text},
+		}},
+		{"{{/* note */}}{{range $i, $x := .Items}}", []Token{
+			{Value: "{{/*", Type: Comment},
+			{Value: " note ", Type: Comment},
+			{Value: "*/}}", Type: Comment},
+			{Value: "{{", Type: Punctuation},
+			{Value: "range", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "$i", Type: Tag},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "$x", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: ":=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: ".Items", Type: Attribute},
+			{Value: "}}", Type: Punctuation},
+		}},
+		{`{{if eq .N 1.5 | not}}{{template "t" .}}{{end}}`, []Token{
+			{Value: "{{", Type: Punctuation},
+			{Value: "if", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "eq", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: ".N", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "1.5", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "not", Type: Attribute},
+			{Value: "}}", Type: Punctuation},
+			{Value: "{{", Type: Punctuation},
+			{Value: "template", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: `"t"`, Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: ".", Type: Punctuation},
+			{Value: "}}", Type: Punctuation},
+			{Value: "{{", Type: Punctuation},
+			{Value: "end", Type: Keyword},
+			{Value: "}}", Type: Punctuation},
+		}},
+	} {
+		assert.Equal(t, item.Tokens,
+			tokenize(t, lexers.GoTemplate, item.Subject), item.Subject)
+	}
+}
+
+func TestLexerGoTemplateHost(t *testing.T) {
+	// The host sees the text as if the actions were absent, splitting the
+	// attribute value around the action
+	assert.Equal(t, []Token{
+		{Value: "<", Type: Punctuation},
+		{Value: "b", Type: Tag},
+		{Value: " ", Type: Whitespace},
+		{Value: "class", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: `"`, Type: Punctuation},
+		{Value: "a", Type: String},
+		{Value: "{{", Type: Punctuation},
+		{Value: ".X", Type: Attribute},
+		{Value: "}}", Type: Punctuation},
+		{Value: "b", Type: String},
+		{Value: `"`, Type: Punctuation},
+		{Value: ">", Type: Punctuation},
+	}, tokenize(t, lexers.GoTemplateHTML, `<b class="a{{.X}}b">`))
+
+	assert.Equal(t, []Token{
+		{Value: "k", Type: Attribute},
+		{Value: ":", Type: Assignment},
+		{Value: " ", Type: Whitespace},
+		{Value: "{{", Type: Punctuation},
+		{Value: ".V", Type: Attribute},
+		{Value: "}}", Type: Punctuation},
+		{Value: " ", Type: Whitespace},
+		{Value: "# c", Type: Comment},
+	}, tokenize(t, lexers.NewGoTemplate(lexers.YAML), "k: {{.V}} # c"))
+}
+
+// failingHost emits the first byte of its input as a Tag, then fails.
+type failingHost struct {
+	Lexer
+}
+
+func (failingHost) Tokenize(r *bufio.Reader, emit func(Token) error) error {
+	b, _ := r.ReadByte()
+	if err := emit(Token{Value: string(b), Type: Tag}); err != nil {
+		return err
+	}
+	return errors.New("host failed")
+}
+
+func TestLexerGoTemplateHostFailure(t *testing.T) {
+	// The text the host didn't get to is emitted as-is
+	assert.Equal(t, []Token{
+		{Value: "a", Type: Tag},
+		{Value: "b", Type: Text},
+		{Value: "{{", Type: Punctuation},
+		{Value: ".X", Type: Attribute},
+		{Value: "}}", Type: Punctuation},
+		{Value: "c", Type: Text},
+	}, tokenize(t, lexers.NewGoTemplate(failingHost{}), "ab{{.X}}c"))
+}
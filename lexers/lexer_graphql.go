@@ -0,0 +1,211 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// graphqlName matches a GraphQL name, e.g. a field, type or argument
+const graphqlName = `[_A-Za-z][_0-9A-Za-z]*`
+
+// graphqlType matches a reference to a type, e.g. `[User!]!`
+const graphqlType = `(\[*)(` + graphqlName + `)((?:[ \t]*[\]!])*)`
+
+// GraphQL matches GraphQL executable documents, such as queries and
+// fragments, and type system definitions written in SDL. Type names are
+// emitted as Tag, and variables and argument names as Attribute.
+var GraphQL = Lexer{
+	Name:      "graphql",
+	Aliases:   []string{"gql", "graphqls"},
+	MimeTypes: []string{"application/graphql", "text/x-graphql"},
+	Filenames: []string{"*.graphql", "*.graphqls", "*.gql"},
+	States: StatesSpec{
+		"root": {
+			{Include: "ignored"},
+			{Include: "strings"},
+			{Regexp: `(query|mutation|subscription)\b`, Type: Keyword,
+				State: "operation"},
+			// Fragments, e.g. `fragment comparisonFields on Character`
+			{Regexp: `(fragment)(\s+)(` + graphqlName + `)(\s+)(on)(\s+)(` +
+				graphqlName + `)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Tag, Whitespace,
+					Keyword, Whitespace, Tag},
+				State: "operation"},
+			{Regexp: `\{`, Type: Punctuation, State: "selectionSet"},
+			{Regexp: `extend\b`, Type: Keyword},
+			{Regexp: `(type|interface|input|schema)\b`, Type: Keyword,
+				State: "typeDefinition"},
+			{Regexp: `(enum)(\s+)(` + graphqlName + `)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Tag},
+				State:    "enumDefinition"},
+			{Regexp: `(union)(\s+)(` + graphqlName + `)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Tag},
+				State:    "unionMembers"},
+			{Regexp: `(scalar)(\s+)(` + graphqlName + `)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Tag}},
+			{Regexp: `(directive)(\s+)(@` + graphqlName + `)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Tag}},
+			{Regexp: `\(`, Type: Punctuation, State: "argumentsDefinition"},
+			{Regexp: `(?:repeatable|on)\b`, Type: Keyword},
+			{Regexp: `\|`, Type: Punctuation},
+			// Directive locations, e.g. `FIELD_DEFINITION`
+			{Regexp: graphqlName, Type: Text},
+		},
+		// ignored matches insignificant whitespace, commas and comments
+		"ignored": {
+			{Regexp: `[\s,]+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+		},
+		"strings": {
+			{Regexp: `"""`, Type: String, State: "blockString"},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"`, Type: String},
+		},
+		// blockString matches the contents of a `"""` block string, in
+		// which only `\"""` is escaped.
+		"blockString": {
+			{Regexp: `"""`, Type: String, State: "#pop"},
+			{Regexp: `\\"""`, Type: Literal},
+			{Regexp: `[^"\\]+|["\\]`, Type: String},
+		},
+		"directives": {
+			{Regexp: `@` + graphqlName, Type: Tag},
+			{Regexp: `\(`, Type: Punctuation, State: "arguments"},
+		},
+		// operation matches the name, variables and directives of an
+		// operation or fragment, up to its selection set.
+		"operation": {
+			{Include: "ignored"},
+			{Regexp: `\{`, Type: Punctuation, State: "#pop selectionSet"},
+			{Regexp: `\(`, Type: Punctuation, State: "variableDefinitions"},
+			{Regexp: `@` + graphqlName, Type: Tag},
+			{Regexp: graphqlName, Type: Tag},
+		},
+		"variableDefinitions": {
+			{Regexp: `\)`, Type: Punctuation, State: "#pop"},
+			{Include: "ignored"},
+			{Regexp: `(\$` + graphqlName + `)(\s*)(:)(\s*)` + graphqlType,
+				SubTypes: []TokenType{Attribute, Whitespace, Punctuation,
+					Whitespace, Punctuation, Tag, Punctuation}},
+			{Regexp: `=`, Type: Assignment},
+			{Include: "directives"},
+			{Include: "values"},
+		},
+		"selectionSet": {
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\{`, Type: Punctuation, State: "selectionSet"},
+			{Include: "ignored"},
+			// Inline fragments, e.g. `... on Droid`
+			{Regexp: `(\.\.\.)(\s*)(on)(\s+)(` + graphqlName + `)`,
+				SubTypes: []TokenType{Punctuation, Whitespace, Keyword,
+					Whitespace, Tag}},
+			// Fragment spreads, e.g. `...comparisonFields`
+			{Regexp: `(\.\.\.)(\s*)(` + graphqlName + `)`,
+				SubTypes: []TokenType{Punctuation, Whitespace, Tag}},
+			{Regexp: `\.\.\.`, Type: Punctuation},
+			// Aliases, e.g. `empireHero: hero`
+			{Regexp: `(` + graphqlName + `)(\s*)(:)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Punctuation}},
+			{Include: "directives"},
+			{Regexp: graphqlName, Type: Text},
+		},
+		"arguments": {
+			{Regexp: `\)`, Type: Punctuation, State: "#pop"},
+			{Include: "ignored"},
+			{Regexp: `(` + graphqlName + `)(\s*)(:)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Punctuation}},
+			{Include: "values"},
+		},
+		"values": {
+			{Include: "ignored"},
+			{Include: "strings"},
+			{Regexp: `\$` + graphqlName, Type: Attribute},
+			{Regexp: `-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][-+]?[0-9]+)?`,
+				Type: Number},
+			{Regexp: `(?:true|false|null)\b`, Type: Literal},
+			{Regexp: `\[`, Type: Punctuation, State: "list"},
+			{Regexp: `\{`, Type: Punctuation, State: "object"},
+			// Enum values, e.g. `EMPIRE`
+			{Regexp: graphqlName, Type: Text},
+		},
+		"list": {
+			{Regexp: `\]`, Type: Punctuation, State: "#pop"},
+			{Include: "values"},
+		},
+		"object": {
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Include: "ignored"},
+			{Regexp: `(` + graphqlName + `)(\s*)(:)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Punctuation}},
+			{Include: "values"},
+		},
+		// typeDefinition matches the name, interfaces and directives of an
+		// object, interface or input type, or the schema, up to its fields.
+		"typeDefinition": {
+			{Include: "ignored"},
+			{Regexp: `\{`, Type: Punctuation, State: "#pop fieldsDefinition"},
+			{Regexp: `implements\b`, Type: Keyword},
+			{Regexp: `&`, Type: Punctuation},
+			{Include: "directives"},
+			{Regexp: graphqlName, Type: Tag},
+			{Regexp: ``, State: "#pop"},
+		},
+		"fieldsDefinition": {
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Include: "ignored"},
+			{Include: "strings"},
+			{Regexp: `\(`, Type: Punctuation, State: "argumentsDefinition"},
+			{Regexp: `(:)(\s*)` + graphqlType,
+				SubTypes: []TokenType{Punctuation, Whitespace, Punctuation,
+					Tag, Punctuation}},
+			// Default values of input fields
+			{Regexp: `=`, Type: Assignment, State: "defaultValue"},
+			{Include: "directives"},
+			{Regexp: graphqlName, Type: Attribute},
+		},
+		"argumentsDefinition": {
+			{Regexp: `\)`, Type: Punctuation, State: "#pop"},
+			{Include: "ignored"},
+			{Include: "strings"},
+			{Regexp: `(` + graphqlName + `)(\s*)(:)(\s*)` + graphqlType,
+				SubTypes: []TokenType{Attribute, Whitespace, Punctuation,
+					Whitespace, Punctuation, Tag, Punctuation}},
+			{Regexp: `=`, Type: Assignment, State: "defaultValue"},
+			{Include: "directives"},
+		},
+		// defaultValue matches a single value following `=`
+		"defaultValue": {
+			{Regexp: `[\s,]+`, Type: Whitespace},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"`, Type: String, State: "#pop"},
+			{Regexp: `-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][-+]?[0-9]+)?`,
+				Type: Number, State: "#pop"},
+			{Regexp: `(?:true|false|null)\b`, Type: Literal, State: "#pop"},
+			{Regexp: `\[`, Type: Punctuation, State: "#pop list"},
+			{Regexp: `\{`, Type: Punctuation, State: "#pop object"},
+			{Regexp: graphqlName, Type: Text, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+		"enumDefinition": {
+			{Include: "ignored"},
+			{Regexp: `\{`, Type: Punctuation},
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Include: "strings"},
+			{Include: "directives"},
+			{Regexp: graphqlName, Type: Literal},
+		},
+		// unionMembers matches the member types of a union, e.g.
+		// `= Human | Droid`
+		"unionMembers": {
+			{Include: "ignored"},
+			{Include: "directives"},
+			{Regexp: `[=|]`, Type: Punctuation, State: "unionMember"},
+			{Regexp: ``, State: "#pop"},
+		},
+		"unionMember": {
+			{Include: "ignored"},
+			{Regexp: graphqlName, Type: Tag, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(GraphQL.Name, GraphQL)
+}
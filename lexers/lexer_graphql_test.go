@@ -0,0 +1,147 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerGraphQL(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{`query Crew($id: ID!) { captain: member(id: $id) { ...name } }`,
+			[]Token{
+				{Value: "query", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: "Crew", Type: Tag},
+				{Value: "(", Type: Punctuation},
+				{Value: "$id", Type: Attribute},
+				{Value: ":", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "ID", Type: Tag},
+				{Value: "!", Type: Punctuation},
+				{Value: ")", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "{", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "captain", Type: Attribute},
+				{Value: ":", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "member", Type: Text},
+				{Value: "(", Type: Punctuation},
+				{Value: "id", Type: Attribute},
+				{Value: ":", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "$id", Type: Attribute},
+				{Value: ")", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "{", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "...", Type: Punctuation},
+				{Value: "name", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "}", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "}", Type: Punctuation},
+			}},
+		{`{ ship @include(if: true) { ... on Freighter { cargo } } }`,
+			[]Token{
+				{Value: "{", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "ship", Type: Text},
+				{Value: " ", Type: Whitespace},
+				{Value: "@include", Type: Tag},
+				{Value: "(", Type: Punctuation},
+				{Value: "if", Type: Attribute},
+				{Value: ":", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "true", Type: Literal},
+				{Value: ")", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "{", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "...", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "on", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: "Freighter", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "{", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "cargo", Type: Text},
+				{Value: " ", Type: Whitespace},
+				{Value: "}", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "}", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "}", Type: Punctuation},
+			}},
+		{"\"\"\"Crew\"\"\"\ntype Person implements Node {\n" +
+			"  ships(first: Int = 10): [Ship!]!\n}",
+			[]Token{
+				{Value: `"""`, Type: String},
+				{Value: "Crew", Type: String},
+				{Value: `"""`, Type: String},
+				{Value: "\n", Type: Whitespace},
+				{Value: "type", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: "Person", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "implements", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: "Node", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "{", Type: Punctuation},
+				{Value: "\n", Type: Whitespace},
+				{Value: "  ", Type: Whitespace},
+				{Value: "ships", Type: Attribute},
+				{Value: "(", Type: Punctuation},
+				{Value: "first", Type: Attribute},
+				{Value: ":", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "Int", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "=", Type: Assignment},
+				{Value: " ", Type: Whitespace},
+				{Value: "10", Type: Number},
+				{Value: ")", Type: Punctuation},
+				{Value: ":", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "[", Type: Punctuation},
+				{Value: "Ship", Type: Tag},
+				{Value: "!]!", Type: Punctuation},
+				{Value: "\n", Type: Whitespace},
+				{Value: "}", Type: Punctuation},
+			}},
+		{"union Crew = Human | Robot\nenum Rank { CAPTAIN }", []Token{
+			{Value: "union", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "Crew", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "Human", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "Robot", Type: Tag},
+			{Value: "\n", Type: Whitespace},
+			{Value: "enum", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "Rank", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "CAPTAIN", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: "}", Type: Punctuation},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.GraphQL,
+			item.Subject), item.Subject)
+	}
+}
@@ -1,45 +1,347 @@
 package lexers
 
-import . "github.com/johnsto/go-highlight"
+import (
+	"regexp"
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// htmlTagName matches the name of an element, including custom elements
+// and namespaced names.
+const htmlTagName = `[A-Za-z][\w:.-]*`
 
 var HTML = Lexer{
 	Name:      "html",
+	Aliases:   []string{"htm", "xhtml"},
 	MimeTypes: []string{"text/html", "application/xhtml+xml"},
 	Filenames: []string{"*.html", "*.htm", "*.xhtml"},
 	States: StatesSpec{
 		"root": {
-			{Regexp: "[^<&]+", Type: Text},
-			{Regexp: "&\\S+?;", Type: Tag},
-			{Regexp: "<!--", Type: Comment, State: "comment"},
-			{Regexp: "(<)(![^>]*)(>)",
-				SubTypes: []TokenType{Punctuation, Tag, Punctuation}},
-			{Regexp: "(</?)([\\w-]*:?[\\w-]+)(\\s*)(>)",
-				SubTypes: []TokenType{Punctuation, Tag, Text, Punctuation}},
-			{Regexp: "(<)([\\w-]*:?[\\w-]+)(\\s*)",
-				SubTypes: []TokenType{Punctuation, Tag, Text},
+			{Regexp: `[^<&]+`, Type: Text},
+			{Include: "entity"},
+			// Conditional comments, e.g. `<!--[if IE]>` and `<![endif]-->`
+			{Regexp: `(<!--\[|<!\[)(if[^\]]*|endif)(\]-->|\]>)`,
+				SubTypes: []TokenType{Comment, Tag, Comment}},
+			{Regexp: `<!--`, Type: Comment, State: "comment"},
+			{Regexp: `(<!)((?i)doctype)`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "doctype"},
+			{Regexp: `(<!)([^>]*)(>)`,
+				SubTypes: []TokenType{Punctuation, Comment, Punctuation}},
+			{Regexp: `(</)(` + htmlTagName + `)`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "closeTag"},
+			// Elements whose content is matched by a state of its own. The
+			// name must be followed by whitespace or the end of the tag, so
+			// custom elements such as `<script-loader>` aren't mistaken for
+			// them.
+			{Regexp: `(<)((?i)script)(\s+)`,
+				SubTypes: []TokenType{Punctuation, Tag, Whitespace},
+				State:    "scriptTag"},
+			{Regexp: `(<)((?i)script)(/?)(>)`,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation,
+					Punctuation},
+				State: "script"},
+			{Regexp: `(<)((?i)style)(\s+)`,
+				SubTypes: []TokenType{Punctuation, Tag, Whitespace},
+				State:    "styleTag"},
+			{Regexp: `(<)((?i)style)(/?)(>)`,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation,
+					Punctuation},
+				State: "style"},
+			{Regexp: `(<)((?i)textarea|title)(\s+)`,
+				SubTypes: []TokenType{Punctuation, Tag, Whitespace},
+				State:    "rcdataTag"},
+			{Regexp: `(<)((?i)textarea|title)(/?)(>)`,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation,
+					Punctuation},
+				State: "rcdata"},
+			{Regexp: `(<)((?i)xmp|iframe|noembed|noframes)(\s+)`,
+				SubTypes: []TokenType{Punctuation, Tag, Whitespace},
+				State:    "rawTextTag"},
+			{Regexp: `(<)((?i)xmp|iframe|noembed|noframes)(/?)(>)`,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation,
+					Punctuation},
+				State: "rawText"},
+			{Regexp: `(<)(` + htmlTagName + `)`,
+				SubTypes: []TokenType{Punctuation, Tag},
 				State:    "tag"},
+			// A `<` or `&` that doesn't begin markup is just text
+			{Regexp: `[<&]`, Type: Text},
+		},
+		// entity matches named and numeric character references
+		"entity": {
+			{Regexp: `&(?:[A-Za-z][A-Za-z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`,
+				Type: Literal},
 		},
 		"comment": {
-			{Regexp: "-->", Type: Comment, State: "#pop"},
-			{Regexp: "[^-]+", Type: Comment},
+			{Regexp: `-->`, Type: Comment, State: "#pop"},
+			{Regexp: `[^-]+|-`, Type: Comment},
+		},
+		"doctype": {
+			{Regexp: `>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `"[^"]*"|'[^']*'`, Type: String},
+			{Regexp: `[^\s>"']+`, Type: Literal},
+		},
+		"closeTag": {
+			{Regexp: `>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+		},
+		// attributes matches the attributes of a start tag, which may span
+		// several lines
+		"attributes": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `([^\s"'>/=]+)(\s*)(=)(\s*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "attributeValue"},
+			{Regexp: `[^\s"'>/=]+`, Type: Attribute},
+			{Regexp: `/`, Type: Punctuation},
+		},
+		"attributeValue": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `"`, Type: Punctuation, State: "#pop doubleQuoted"},
+			{Regexp: `'`, Type: Punctuation, State: "#pop singleQuoted"},
+			{Regexp: "[^\\s\"'=<>`]+", Type: String, State: "#pop"},
+			// Missing value; let the tag continue
+			{Regexp: `>`, Type: Punctuation, State: "#pop #pop"},
+		},
+		"doubleQuoted": {
+			{Regexp: `"`, Type: Punctuation, State: "#pop"},
+			{Include: "entity"},
+			{Regexp: `[^"&]+|&`, Type: String},
+		},
+		"singleQuoted": {
+			{Regexp: `'`, Type: Punctuation, State: "#pop"},
+			{Include: "entity"},
+			{Regexp: `[^'&]+|&`, Type: String},
 		},
 		"tag": {
-			{Regexp: "([\\w-]+)(=)(\\s*)",
-				SubTypes: []TokenType{Attribute, Operator, Text},
-				State:    "tagAttr"},
-			{Regexp: "[\\w-]+\\s*", Type: Attribute},
-			{Regexp: "\\s+", Type: Tag},
-			{Regexp: "(/?)(\\s*)(>)",
-				SubTypes: []TokenType{Punctuation, Tag, Punctuation},
-				State:    "#pop",
-			},
-		},
-		"tagAttr": {
-			{Regexp: "\"[^\"]*\"", Type: String, State: "#pop"},
-			{Regexp: "'[^']*'", Type: String, State: "#pop"},
-			{Regexp: "\\w+", Type: String, State: "#pop"},
+			{Regexp: `(/?)(\s*)(>)`,
+				SubTypes: []TokenType{Punctuation, Whitespace, Punctuation},
+				State:    "#pop"},
+			{Include: "attributes"},
+		},
+		// scriptTag records the script's type, so that its content can be
+		// highlighted accordingly
+		"scriptTag": {
+			{Regexp: `(/?)(\s*)(>)`,
+				SubTypes: []TokenType{Punctuation, Whitespace, Punctuation},
+				State:    "#pop script"},
+			{Regexp: `((?i)type)(\s*)(=)(\s*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "scriptType"},
+			{Include: "attributes"},
+		},
+		"scriptType": {
+			{Regexp: `"[^"]*"|'[^']*'|[^\s"'=<>]+`, Type: String,
+				State: "#pop"},
+			{Include: "attributeValue"},
+		},
+		// script matches the content of a <script> element, which is
+		// delegated to the Tokenizer for its type
+		"script": {
+			{Regexp: `(</)((?i)script)\b`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "#pop closeTag"},
+			{Regexp: `[^<]+|<`, Type: Text},
+		},
+		"styleTag": {
+			{Regexp: `(/?)(\s*)(>)`,
+				SubTypes: []TokenType{Punctuation, Whitespace, Punctuation},
+				State:    "#pop style"},
+			{Include: "attributes"},
+		},
+		// style matches the content of a <style> element, which is
+		// delegated to the CSS lexer
+		"style": {
+			{Regexp: `(</)((?i)style)\b`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "#pop closeTag"},
+			{Regexp: `[^<]+|<`, Type: Text},
+		},
+		// rcdata matches the content of elements such as <textarea>, which
+		// may contain character references but not other elements
+		"rcdataTag": {
+			{Regexp: `(/?)(\s*)(>)`,
+				SubTypes: []TokenType{Punctuation, Whitespace, Punctuation},
+				State:    "#pop rcdata"},
+			{Include: "attributes"},
+		},
+		"rcdata": {
+			{Regexp: `(</)((?i)textarea|title)\b`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "#pop closeTag"},
+			{Include: "entity"},
+			{Regexp: `[^<&]+|[<&]`, Type: Text},
+		},
+		"rawTextTag": {
+			{Regexp: `(/?)(\s*)(>)`,
+				SubTypes: []TokenType{Punctuation, Whitespace, Punctuation},
+				State:    "#pop rawText"},
+			{Include: "attributes"},
+		},
+		"rawText": {
+			{Regexp: `(</)((?i)xmp|iframe|noembed|noframes)\b`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "#pop closeTag"},
+			{Regexp: `[^<]+|<`, Type: Text},
+		},
+	},
+	Filters: []Filter{
+		RemoveEmptiesFilter,
+		DelegateFilter{
+			States: []string{"script", "style"},
+			Types:  []TokenType{Text},
+			Select: selectHTMLTokenizer,
 		},
 	},
+	Minifier: HTMLMinifier,
+}
+
+// selectHTMLTokenizer selects a Tokenizer for the content of <style> and
+// <script> elements, the latter based upon the element's type attribute.
+func selectHTMLTokenizer(t Token, current Tokenizer) Tokenizer {
+	switch {
+	case t.Type == Tag && t.State == "root":
+		switch strings.ToLower(t.Value) {
+		case "style":
+			return CSS
+		case "script":
+			return htmlScriptTokenizer("")
+		}
+	case t.Type == String && t.State == "scriptType":
+		return htmlScriptTokenizer(strings.Trim(t.Value, `"'`))
+	}
+	return current
+}
+
+// htmlScriptTokenizer returns the Tokenizer for the given script type, or
+// nil if the type isn't known.
+func htmlScriptTokenizer(mediaType string) Tokenizer {
+	mediaType = strings.TrimSpace(mediaType)
+	if mediaType == "" || strings.EqualFold(mediaType, "module") {
+		mediaType = "application/javascript"
+	}
+	tokenizer, err := GetTokenizerForContentType(mediaType)
+	if err != nil {
+		return nil
+	}
+	return tokenizer
+}
+
+// htmlRawElements lists the elements whose contents are whitespace-sensitive
+// and so must not be minified.
+var htmlRawElements = map[string]bool{
+	"pre":      true,
+	"textarea": true,
+	"script":   true,
+	"style":    true,
+}
+
+var htmlSpace = regexp.MustCompile(`\s+`)
+
+// HTMLMinifier removes comments and collapses insignificant whitespace in
+// HTML tokens. Runs of whitespace in text are reduced to a single space, as
+// a browser would render them, except within whitespace-sensitive elements
+// such as <pre> and <textarea>.
+var HTMLMinifier = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		// raw counts the number of open whitespace-sensitive elements
+		raw := 0
+		// inTag is set between the opening `<` and closing `>` of a tag
+		inTag := false
+		// closing is set if the current tag is a closing tag
+		closing := false
+		// name is set if the next Tag token names the element
+		name := false
+		// space is set if whitespace has been dropped within a tag
+		space := false
+		// lastSpace is set if the last emitted token ended in whitespace
+		lastSpace := false
+
+		emit := func(t Token) error {
+			lastSpace = strings.TrimRight(t.Value, " ") != t.Value
+			return out(t)
+		}
+
+		return func(t Token) error {
+			if t.Type == "" {
+				return out(t)
+			} else if t.Value == "" {
+				return nil
+			}
+
+			if !inTag {
+				switch {
+				case t.Type == Punctuation && (t.Value == "</" ||
+					raw == 0 && strings.HasPrefix(t.Value, "<")):
+					inTag, closing, name = true, t.Value == "</", true
+					return emit(t)
+				case raw > 0:
+					return emit(t)
+				case t.Type == Comment && t.State == "root" && t.Value != "<!--":
+					// Keep conditional comments, which are significant
+					return emit(t)
+				case t.Type == Comment:
+					return nil
+				}
+				t.Value = htmlSpace.ReplaceAllString(t.Value, " ")
+				if lastSpace {
+					t.Value = strings.TrimLeft(t.Value, " ")
+				}
+				if t.Value == "" {
+					return nil
+				}
+				return emit(t)
+			}
+
+			// Attribute values are kept as written
+			if t.Type == String || t.State == "doubleQuoted" ||
+				t.State == "singleQuoted" {
+				space = false
+				return emit(t)
+			}
+
+			// Within a tag, whitespace only serves to separate attributes
+			v := strings.TrimFunc(t.Value, isHTMLSpace)
+			if v == "" {
+				space = true
+				return nil
+			}
+			space = space || strings.TrimLeftFunc(t.Value, isHTMLSpace) != t.Value
+
+			if t.Type == Punctuation && v == ">" {
+				inTag, space = false, false
+			} else if space {
+				if err := emit(Token{Value: " ", Type: Whitespace,
+					State: t.State}); err != nil {
+					return err
+				}
+			}
+			space = strings.TrimRightFunc(t.Value, isHTMLSpace) != t.Value
+
+			if name && t.Type == Tag {
+				if htmlRawElements[strings.ToLower(v)] {
+					if closing && raw > 0 {
+						raw--
+					} else if !closing {
+						raw++
+					}
+				}
+				name = false
+			}
+
+			t.Value = v
+			return emit(t)
+		}
+	})
+
+func isHTMLSpace(r rune) bool {
+	return strings.ContainsRune(" \t\r\n\f", r)
 }
 
 func init() {
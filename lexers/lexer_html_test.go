@@ -0,0 +1,157 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerHTML(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"<!doctype html>", []Token{
+			{Value: "<!", Type: Punctuation},
+			{Value: "doctype", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "html", Type: Literal},
+			{Value: ">", Type: Punctuation},
+		}},
+		{"a &amp; &#169;&#x1f600; & b < c", []Token{
+			{Value: "a ", Type: Text},
+			{Value: "&amp;", Type: Literal},
+			{Value: " ", Type: Text},
+			{Value: "&#169;", Type: Literal},
+			{Value: "&#x1f600;", Type: Literal},
+			{Value: " ", Type: Text},
+			{Value: "&", Type: Text},
+			{Value: " b ", Type: Text},
+			{Value: "<", Type: Text},
+			{Value: " c", Type: Text},
+		}},
+		{"<a\n  href=\"/?a=1&amp;b\" data-x=a/b\n  hidden>", []Token{
+			{Value: "<", Type: Punctuation},
+			{Value: "a", Type: Tag},
+			{Value: "\n", Type: Whitespace},
+			{Value: "  ", Type: Whitespace},
+			{Value: "href", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: `"`, Type: Punctuation},
+			{Value: "/?a=1", Type: String},
+			{Value: "&amp;", Type: Literal},
+			{Value: "b", Type: String},
+			{Value: `"`, Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "data-x", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: "a/b", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "  ", Type: Whitespace},
+			{Value: "hidden", Type: Attribute},
+			{Value: ">", Type: Punctuation},
+		}},
+		{"<br/><img src=x.png />", []Token{
+			{Value: "<", Type: Punctuation},
+			{Value: "br", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: ">", Type: Punctuation},
+			{Value: "<", Type: Punctuation},
+			{Value: "img", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "src", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: "x.png", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "/", Type: Punctuation},
+			{Value: ">", Type: Punctuation},
+		}},
+		{"<!--[if IE]><p>IE</p><![endif]--><!-- x -->", []Token{
+			{Value: "<!--[", Type: Comment},
+			{Value: "if IE", Type: Tag},
+			{Value: "]>", Type: Comment},
+			{Value: "<", Type: Punctuation},
+			{Value: "p", Type: Tag},
+			{Value: ">", Type: Punctuation},
+			{Value: "IE", Type: Text},
+			{Value: "</", Type: Punctuation},
+			{Value: "p", Type: Tag},
+			{Value: ">", Type: Punctuation},
+			{Value: "<![", Type: Comment},
+			{Value: "endif", Type: Tag},
+			{Value: "]-->", Type: Comment},
+			{Value: "<!--", Type: Comment},
+			{Value: " x ", Type: Comment},
+			{Value: "-->", Type: Comment},
+		}},
+		{"<textarea><b>&lt;</textarea>", []Token{
+			{Value: "<", Type: Punctuation},
+			{Value: "textarea", Type: Tag},
+			{Value: ">", Type: Punctuation},
+			{Value: "<", Type: Text},
+			{Value: "b>", Type: Text},
+			{Value: "&lt;", Type: Literal},
+			{Value: "</", Type: Punctuation},
+			{Value: "textarea", Type: Tag},
+			{Value: ">", Type: Punctuation},
+		}},
+		{"<style>a { top: 0; }</style>", []Token{
+			{Value: "<", Type: Punctuation},
+			{Value: "style", Type: Tag},
+			{Value: ">", Type: Punctuation},
+			{Value: "a", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "top", Type: Tag},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "0", Type: Number},
+			{Value: ";", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "}", Type: Punctuation},
+			{Value: "</", Type: Punctuation},
+			{Value: "style", Type: Tag},
+			{Value: ">", Type: Punctuation},
+		}},
+		{`<script type="text/x-template"><p>{{ x }}</p></script>`, []Token{
+			{Value: "<", Type: Punctuation},
+			{Value: "script", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "type", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: `"text/x-template"`, Type: String},
+			{Value: ">", Type: Punctuation},
+			{Value: "<", Type: Text},
+			{Value: "p>{{ x }}", Type: Text},
+			{Value: "<", Type: Text},
+			{Value: "/p>", Type: Text},
+			{Value: "</", Type: Punctuation},
+			{Value: "script", Type: Tag},
+			{Value: ">", Type: Punctuation},
+		}},
+		// Custom elements aren't mistaken for <script> or <style>
+		{"<script-loader>a / b</script-loader><style-guide>{</style-guide>",
+			[]Token{
+				{Value: "<", Type: Punctuation},
+				{Value: "script-loader", Type: Tag},
+				{Value: ">", Type: Punctuation},
+				{Value: "a / b", Type: Text},
+				{Value: "</", Type: Punctuation},
+				{Value: "script-loader", Type: Tag},
+				{Value: ">", Type: Punctuation},
+				{Value: "<", Type: Punctuation},
+				{Value: "style-guide", Type: Tag},
+				{Value: ">", Type: Punctuation},
+				{Value: "{", Type: Text},
+				{Value: "</", Type: Punctuation},
+				{Value: "style-guide", Type: Tag},
+				{Value: ">", Type: Punctuation},
+			}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.HTML, item.Subject),
+			item.Subject)
+	}
+}
@@ -1,36 +1,203 @@
 package lexers
 
-import . "github.com/johnsto/go-highlight"
+import (
+	"fmt"
+	"strconv"
+	"strings"
 
+	. "github.com/johnsto/go-highlight"
+)
+
+// HTTP matches HTTP/1.x messages, such as those captured by a proxy or
+// written by `curl -i`. Multiple messages may follow one another, and the
+// body of each message is highlighted by the Tokenizer registered for its
+// Content-Type, if any. The data of a chunked body is highlighted as a
+// whole, around the size of each chunk.
 var HTTP = Lexer{
-	Name: "http",
-	States: StatesSpec{
+	Name:      "http",
+	MimeTypes: []string{"message/http"},
+	Filenames: []string{"*.http"},
+	States: httpStates{StatesSpec{
 		"root": {
-			// Request
-			{Regexp: `^([a-zA-Z]+)( )([^ ]+)( )(HTTP)(/)([0-9\.]+)(\r\n)$`,
+			{Include: "startLine"},
+			{Regexp: `\r?\n`, Type: Whitespace},
+		},
+		// startLine matches the request or status line starting a message
+		"startLine": {
+			// Request, e.g. `GET /index.html HTTP/1.1`
+			{Regexp: `([A-Za-z][A-Za-z-]*)( +)([^ \r\n]+)( +)(HTTP)(/)` +
+				`([0-9\.]+)([ \t]*\r?\n)`,
 				SubTypes: []TokenType{Tag, Whitespace, String, Whitespace,
 					Tag, Punctuation, Tag, Whitespace},
-				State: "headers"},
-			// Response
-			{Regexp: `^(HTTP)(/)([0-9\.]+)( )([0-9]+)(.*)(\r\n)$`,
+				State: "#reset headers"},
+			// Response, e.g. `HTTP/1.1 200 OK`
+			{Regexp: `(HTTP)(/)([0-9\.]+)( +)([0-9]+)([ \t]*)([^\r\n]*)` +
+				`(\r?\n)`,
 				SubTypes: []TokenType{Tag, Punctuation, Tag,
 					Whitespace, Number, Whitespace, String, Whitespace},
-				State: "headers"},
+				State: "#reset headers"},
 		},
+		// headers matches each header field, until the blank line preceding
+		// the body
 		"headers": {
-			{Regexp: `^(.*?)(:)(\s*)`,
-				SubTypes: []TokenType{Attribute, Assignment, Whitespace},
-				State:    "headerValue"},
-			{Regexp: `^\r\n$`, State: "#pop #pop"},
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop body"},
+			{Include: "header"},
+		},
+		"header": {
+			// Obsolete line folding continues the previous value
+			{Regexp: `([ \t]+)([^\r\n]*)(\r?\n)`,
+				SubTypes: []TokenType{Whitespace, Text, Whitespace}},
+			{Regexp: `(?i)(content-type)([ \t]*)(:)([ \t]*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "contentType"},
+			{Regexp: `(?i)(transfer-encoding)([ \t]*)(:)([ \t]*)` +
+				`([^\r\n]*chunked[^\r\n]*)(\r?\n)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace, Text, Whitespace},
+				State: "#pop chunkedHeaders"},
+			{Regexp: `([^:\r\n]+)([ \t]*)(:)([ \t]*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "headerValue"},
+
+			// Malformed header lines, lacking a colon
+			{Regexp: `([^\r\n:]+)(\r?\n)`,
+				SubTypes: []TokenType{Error, Whitespace}},
 		},
 		"headerValue": {
-			{Regexp: `\r\n$`, State: "#pop", Type: Whitespace},
-			{Regexp: `[^;]+?`, Type: Text},
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
 			{Regexp: `;`, Type: Punctuation},
-			{Regexp: `\r\n$`, State: "#pop"},
+			{Regexp: `[^;\r\n]+`, Type: Text},
+		},
+		// contentType matches the media type and any parameters
+		"contentType": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `(;)([^;\r\n]*)`,
+				SubTypes: []TokenType{Punctuation, Text}},
+			{Regexp: `([^;\s]+)([ \t]*)`,
+				SubTypes: []TokenType{String, Whitespace}},
+		},
+		// body matches each line of the body, until the start of the next
+		// message
+		"body": {
+			{Include: "startLine"},
+			{Regexp: `[^\n]+\n?|\n`, Type: Text},
+		},
+		// chunkedHeaders behaves as headers, but is followed by a body
+		// using chunked transfer encoding
+		"chunkedHeaders": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop chunked"},
+			{Include: "header"},
+		},
+		// chunked matches the size of each chunk, whose data is passed to
+		// the chunkData state
+		"chunked": {
+			// Last chunk, followed by optional trailer fields
+			{Regexp: `(0+)((?:;[^\r\n]*)?)([ \t]*\r?\n)`,
+				SubTypes: []TokenType{Number, Attribute, Whitespace},
+				State:    "#pop trailers"},
+			{Regexp: `([0-9a-fA-F]+)((?:;[^\r\n]*)?)([ \t]*\r?\n)`,
+				SubTypes: []TokenType{Number, Attribute, Whitespace},
+				Capture:  1, State: "chunkData"},
+			// Malformed chunks
+			{Regexp: `[^\r\n]+`, Type: Error},
+			{Regexp: `\r?\n`, Type: Whitespace},
+		},
+		// chunkEnd matches the line ending following the data of a chunk
+		"chunkEnd": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+		"trailers": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Include: "header"},
+		},
+	}},
+	Filters: []Filter{
+		RemoveEmptiesFilter,
+		DelegateFilter{
+			States: []string{"body", "chunkData"},
+			Types:  []TokenType{Text},
+			Gaps:   []string{"chunked", "chunkEnd"},
+			Select: selectHTTPBodyTokenizer,
 		},
 	},
-	Filters: []Filter{},
+}
+
+// httpStates adds the `chunkData` state to the states of the HTTP lexer.
+type httpStates struct {
+	StatesSpec
+}
+
+func (s httpStates) Compile() (States, error) {
+	states, err := s.StatesSpec.Compile()
+	if err != nil {
+		return nil, err
+	}
+	return httpStateMap{states.(*StateMap)}, nil
+}
+
+// httpStateMap is the compiled form of httpStates.
+type httpStateMap struct {
+	*StateMap
+}
+
+// Get returns the named state. A name of the form `chunkData:size` refers
+// to the data of a chunk with the given number of bytes remaining, in
+// hexadecimal.
+func (m httpStateMap) Get(name string) State {
+	if !strings.HasPrefix(name, "chunkData:") {
+		return m.StateMap.Get(name)
+	}
+	size, err := strconv.ParseInt(strings.TrimPrefix(name, "chunkData:"),
+		16, 64)
+	if err != nil || size <= 0 {
+		return State{NewRegexpRule(``, "", nil, []string{"#pop", "chunkEnd"})}
+	}
+	return State{httpChunkData(size)}
+}
+
+// httpChunkData is a Rule matching up to the given number of bytes of chunk
+// data, regardless of their content.
+type httpChunkData int64
+
+func (r httpChunkData) Find(subject string) (int, Rule) {
+	return 0, r
+}
+
+func (r httpChunkData) Match(subject string) (int, Rule, []Token, error) {
+	n := len(subject)
+	if int64(n) > int64(r) {
+		n = int(r)
+	}
+	return n, r - httpChunkData(n), []Token{{Value: subject[:n],
+		Type: Text}}, nil
+}
+
+// Stack continues with the remaining data, if any, once matched.
+func (r httpChunkData) Stack() []string {
+	if r > 0 {
+		return []string{"#pop", fmt.Sprintf("chunkData:%x", int64(r))}
+	}
+	return []string{"#pop", "chunkEnd"}
+}
+
+// selectHTTPBodyTokenizer selects a Tokenizer for the body of each HTTP
+// message based upon its Content-Type header.
+func selectHTTPBodyTokenizer(t Token, current Tokenizer) Tokenizer {
+	switch {
+	case t.Type == Tag:
+		// Start of a new message
+		return nil
+	case t.State == "contentType" && t.Type == String:
+		tokenizer, err := GetTokenizerForContentType(t.Value)
+		if err != nil {
+			return nil
+		}
+		return tokenizer
+	}
+	return current
 }
 
 func init() {
@@ -0,0 +1,257 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerHTTP(t *testing.T) {
+	for _, item := range []struct {
+		Name    string
+		Subject string
+		Tokens  []Token
+	}{{
+		Name:    "request with bare newlines",
+		Subject: "GET /index.html HTTP/1.1\nHost: example.com\n\n",
+		Tokens: []Token{
+			{Value: "GET", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "/index.html", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "HTTP", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: "1.1", Type: Tag},
+			{Value: "\n", Type: Whitespace},
+			{Value: "Host", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "example.com", Type: Text},
+			{Value: "\n", Type: Whitespace},
+			{Value: "\n", Type: Whitespace},
+		},
+	}, {
+		Name: "response with folded header and JSON body",
+		Subject: "HTTP/1.1 200 OK\r\n" +
+			"X-Long: a;\r\n\tb\r\n" +
+			"Content-Type: application/json; charset=utf-8\r\n" +
+			"\r\n" +
+			"{\"a\":\n1}",
+		Tokens: []Token{
+			{Value: "HTTP", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: "1.1", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "200", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "OK", Type: String},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "X-Long", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "a", Type: Text},
+			{Value: ";", Type: Punctuation},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\t", Type: Whitespace},
+			{Value: "b", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "Content-Type", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "application/json", Type: String},
+			{Value: ";", Type: Punctuation},
+			{Value: " charset=utf-8", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: `"`, Type: Punctuation},
+			{Value: "a", Type: Attribute},
+			{Value: `"`, Type: Punctuation},
+			{Value: ":", Type: Assignment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "1", Type: Number},
+			{Value: "}", Type: Punctuation},
+		},
+	}, {
+		Name: "chunked response",
+		Subject: "HTTP/1.1 200 OK\r\n" +
+			"Transfer-Encoding: chunked\r\n" +
+			"\r\n" +
+			"5\r\nhello\r\n" +
+			"0\r\n" +
+			"\r\n",
+		Tokens: []Token{
+			{Value: "HTTP", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: "1.1", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "200", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "OK", Type: String},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "Transfer-Encoding", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "chunked", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "5", Type: Number},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "hello", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "0", Type: Number},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\r\n", Type: Whitespace},
+		},
+	}, {
+		// Chunks are delimited by their size rather than their content
+		Name: "chunked data resembling a size",
+		Subject: "HTTP/1.1 200 OK\r\n" +
+			"Transfer-Encoding: chunked\r\n" +
+			"\r\n" +
+			"6\r\nab\r\ncd\r\n" +
+			"0\r\n" +
+			"\r\n",
+		Tokens: []Token{
+			{Value: "HTTP", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: "1.1", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "200", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "OK", Type: String},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "Transfer-Encoding", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "chunked", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "6", Type: Number},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "ab\r\n", Type: Text},
+			{Value: "cd", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "0", Type: Number},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\r\n", Type: Whitespace},
+		},
+	}, {
+		// The data of each chunk is highlighted as a whole
+		Name: "chunked JSON",
+		Subject: "HTTP/1.1 200 OK\r\n" +
+			"Content-Type: application/json\r\n" +
+			"Transfer-Encoding: chunked\r\n" +
+			"\r\n" +
+			"4\r\n[\"ab\r\n" +
+			"3\r\nc\"]\r\n" +
+			"0\r\n" +
+			"\r\n",
+		Tokens: []Token{
+			{Value: "HTTP", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: "1.1", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "200", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "OK", Type: String},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "Content-Type", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "application/json", Type: String},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "Transfer-Encoding", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "chunked", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "4", Type: Number},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "[", Type: Punctuation},
+			{Value: `"`, Type: Punctuation},
+			{Value: "ab", Type: String},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "3", Type: Number},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "c", Type: String},
+			{Value: `"`, Type: Punctuation},
+			{Value: "]", Type: Punctuation},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "0", Type: Number},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\r\n", Type: Whitespace},
+		},
+	}, {
+		// Only an empty line ends the headers
+		Name: "malformed header",
+		Subject: "GET / HTTP/1.1\r\n" +
+			"Host: x\r\n" +
+			"bad header line\r\n" +
+			"Accept: y\r\n" +
+			"\r\n",
+		Tokens: []Token{
+			{Value: "GET", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "/", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "HTTP", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: "1.1", Type: Tag},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "Host", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "x", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "bad header line", Type: Error},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "Accept", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "y", Type: Text},
+			{Value: "\r\n", Type: Whitespace},
+			{Value: "\r\n", Type: Whitespace},
+		},
+	}, {
+		Name: "pipelined messages",
+		Subject: "POST /a HTTP/1.1\n" +
+			"Content-Type: text/plain\n" +
+			"\n" +
+			"body\n" +
+			"GET /b HTTP/1.1\n" +
+			"\n",
+		Tokens: []Token{
+			{Value: "POST", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "/a", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "HTTP", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: "1.1", Type: Tag},
+			{Value: "\n", Type: Whitespace},
+			{Value: "Content-Type", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "text/plain", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "\n", Type: Whitespace},
+			{Value: "body\n", Type: Text},
+			{Value: "GET", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "/b", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "HTTP", Type: Tag},
+			{Value: "/", Type: Punctuation},
+			{Value: "1.1", Type: Tag},
+			{Value: "\n", Type: Whitespace},
+			{Value: "\n", Type: Whitespace},
+		},
+	}} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.HTTP, item.Subject),
+			item.Name)
+	}
+}
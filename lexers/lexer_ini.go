@@ -0,0 +1,46 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+var INI = Lexer{
+	Name:      "ini",
+	Aliases:   []string{"cfg", "gitconfig"},
+	MimeTypes: []string{"text/x-ini", "text/ini"},
+	Filenames: []string{"*.ini", "*.cfg", ".gitconfig", ".editorconfig"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `[;#][^\r\n]*`, Type: Comment},
+			{Regexp: `(\[)([^\]\r\n]*)(\])`,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation}},
+			{Regexp: `([^\s=:;#\[][^=:\r\n]*?)([ \t]*)([=:])([ \t]*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "value"},
+			// Keys without a value, e.g. `skip-networking`
+			{Regexp: `[^\s=:;#\[][^\r\n]*`, Type: Attribute},
+		},
+		// value matches the remainder of the line following a key, ending
+		// at any comment preceded by whitespace.
+		"value": {
+			{Regexp: `(\\)(\r?\n)`, SubTypes: []TokenType{Punctuation,
+				Whitespace}},
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop continuation"},
+			{Regexp: `[ \t]+[;#][^\r\n]*`, Type: Comment},
+			{Regexp: `[^\s\\]+(?:[ \t]+[^\s;#\\][^\s\\]*)*|[ \t]+|\\`,
+				Type: String},
+		},
+		// continuation matches indented lines following a value that
+		// don't themselves contain a key, which continue the value.
+		"continuation": {
+			{Regexp: `([ \t]+)([^\s=:;#\[][^=:\r\n]*)(\r?\n|$)`,
+				SubTypes: []TokenType{Whitespace, String, Whitespace}},
+			{Regexp: ``, State: "#pop"},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(INI.Name, INI)
+}
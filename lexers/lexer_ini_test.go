@@ -0,0 +1,42 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerINI(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "; c", Type: Comment},
+		{Value: "\n", Type: Whitespace},
+		{Value: "[", Type: Punctuation},
+		{Value: "crew", Type: Tag},
+		{Value: "]", Type: Punctuation},
+		{Value: "\n", Type: Whitespace},
+		{Value: "name", Type: Attribute},
+		{Value: " ", Type: Whitespace},
+		{Value: "=", Type: Assignment},
+		{Value: " ", Type: Whitespace},
+		{Value: "Philip J. Fry", Type: String},
+		{Value: " # c", Type: Comment},
+		{Value: "\n", Type: Whitespace},
+		{Value: "ships", Type: Attribute},
+		{Value: ":", Type: Assignment},
+		{Value: " ", Type: Whitespace},
+		{Value: "Planet Express", Type: String},
+		{Value: "\n", Type: Whitespace},
+		{Value: "  ", Type: Whitespace},
+		{Value: "Nimbus", Type: String},
+		{Value: "\n", Type: Whitespace},
+		{Value: "  ", Type: Whitespace},
+		{Value: "age", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "1000", Type: String},
+		{Value: "\n", Type: Whitespace},
+		{Value: "frozen", Type: Attribute},
+	}, tokenize(t, lexers.INI, "; c\n[crew]\nname = Philip J. Fry # c\n"+
+		"ships: Planet Express\n  Nimbus\n  age=1000\nfrozen"))
+}
@@ -0,0 +1,211 @@
+package lexers
+
+import (
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+const (
+	// jsIdentifier matches an identifier, e.g. `$el` or `_private`
+	jsIdentifier = `[A-Za-z_$][\w$]*`
+	// jsDigits matches digits with optional `_` separators
+	jsDigits = `[0-9](?:_?[0-9])*`
+)
+
+// jsKeywords lists the reserved and contextual keywords of JavaScript.
+var jsKeywords = []string{
+	"as", "async", "await", "break", "case", "catch", "class", "const",
+	"continue", "debugger", "default", "delete", "do", "else", "export",
+	"extends", "finally", "for", "from", "function", "if", "import", "in",
+	"instanceof", "let", "new", "of", "return", "static", "switch", "throw",
+	"try", "typeof", "var", "void", "while", "with", "yield",
+}
+
+// tsKeywords lists the additional keywords of TypeScript.
+var tsKeywords = []string{
+	"abstract", "asserts", "declare", "enum", "implements", "infer",
+	"interface", "is", "keyof", "module", "namespace", "override", "private",
+	"protected", "public", "readonly", "satisfies", "type", "unique",
+}
+
+// tsTypes lists the built-in types of TypeScript, which are operands, such
+// that a following `/` is division, e.g. in `x as number / 2`.
+var tsTypes = []string{
+	"any", "bigint", "boolean", "never", "number", "object", "string",
+	"symbol", "unknown",
+}
+
+var JavaScript = Lexer{
+	Name:    "javascript",
+	Aliases: []string{"js", "node"},
+	MimeTypes: []string{"application/javascript", "text/javascript",
+		"application/x-javascript", "application/ecmascript",
+		"text/ecmascript"},
+	Filenames: []string{"*.js", "*.mjs", "*.cjs"},
+	States:    javaScriptStates(jsKeywords, nil, false),
+	Filters:   []Filter{RemoveEmptiesFilter},
+}
+
+var JSX = Lexer{
+	Name:      "jsx",
+	MimeTypes: []string{"text/jsx"},
+	Filenames: []string{"*.jsx"},
+	States:    javaScriptStates(jsKeywords, nil, true),
+	Filters:   []Filter{RemoveEmptiesFilter},
+}
+
+var TypeScript = Lexer{
+	Name:    "typescript",
+	Aliases: []string{"ts"},
+	MimeTypes: []string{"application/typescript", "application/x-typescript",
+		"text/typescript"},
+	Filenames: []string{"*.ts", "*.mts", "*.cts"},
+	States: javaScriptStates(append(tsKeywords, jsKeywords...),
+		tsTypes, false),
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+var TSX = Lexer{
+	Name:      "tsx",
+	MimeTypes: []string{"text/tsx"},
+	Filenames: []string{"*.tsx"},
+	States: javaScriptStates(append(tsKeywords, jsKeywords...),
+		tsTypes, true),
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+// javaScriptStates returns the states of a JavaScript lexer recognising the
+// given keywords and built-in types, and JSX elements if jsx is set.
+//
+// A `/` may begin either a regular expression or a division, depending on
+// what precedes it. The "root" state is entered after an operand, where `/`
+// is division, while "regexAllowed" is entered after an operator or
+// punctuation, where it begins a regular expression.
+func javaScriptStates(keywords, types []string, jsx bool) StatesSpec {
+	states := StatesSpec{
+		"root": {
+			{Regexp: `#![^\r\n]*`, Type: Comment},
+			{Include: "whitespace"},
+			{Regexp: `/=?`, Type: Operator, State: "regexAllowed"},
+			{Include: "expression"},
+		},
+		"whitespace": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `//[^\r\n]*`, Type: Comment},
+			{Regexp: `/\*`, Type: Comment, State: "comment"},
+		},
+		"comment": {
+			{Regexp: `\*/`, Type: Comment, State: "#pop"},
+			{Regexp: `[^*]+|\*`, Type: Comment},
+		},
+		// regexAllowed is entered wherever an operand is expected
+		"regexAllowed": {
+			{Include: "whitespace"},
+			{Regexp: `/(?:\\.|\[(?:\\.|[^\]\\\r\n])*\]|[^/\\\[\r\n])+/` +
+				`[dgimsuvy]*`,
+				Type: String, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+		"expression": {
+			{Regexp: "`", Type: String, State: "template"},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"|'(?:[^'\\\r\n]|\\.)*'`,
+				Type: String},
+			{Regexp: `(?:0[xX][0-9a-fA-F](?:_?[0-9a-fA-F])*|` +
+				`0[oO][0-7](?:_?[0-7])*|0[bB][01](?:_?[01])*|` +
+				`(?:` + jsDigits + `(?:\.(?:` + jsDigits + `)?)?|\.` + jsDigits +
+				`)(?:[eE][-+]?` + jsDigits + `)?)n?`,
+				Type: Number},
+			// Property access, e.g. `.length` or `?.then`
+			{Regexp: `(\??\.)(#?` + jsIdentifier + `)`,
+				SubTypes: []TokenType{Punctuation, Attribute}},
+			{Regexp: `(?:true|false|null|undefined|NaN|Infinity)\b`,
+				Type: Literal},
+			{Regexp: `(?:this|super)\b`, Type: Keyword},
+			{Regexp: `(?:` + strings.Join(keywords, "|") + `)\b`,
+				Type: Keyword, State: "regexAllowed"},
+			{Regexp: `#?` + jsIdentifier, Type: Text},
+			{Regexp: `@` + jsIdentifier, Type: Tag},
+			{Regexp: `\{`, Type: Punctuation, State: "braces regexAllowed"},
+			{Regexp: `[(\[;,]`, Type: Punctuation, State: "regexAllowed"},
+			{Regexp: `[)\]}]`, Type: Punctuation},
+			{Regexp: `>>>=?|\.\.\.|[=!]==?|\*\*=?|<<=?|>>=?|&&=?|\|\|=?|` +
+				`\?\?=?|=>|\+\+|--|[-+*%&|^<>~?:!]=?`,
+				Type: Operator, State: "regexAllowed"},
+			{Regexp: `=`, Type: Assignment, State: "regexAllowed"},
+		},
+		// braces matches the contents of a block or object literal, so that
+		// the end of a template literal's `${...}` can be found
+		"braces": {
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Include: "root"},
+		},
+		"template": {
+			{Regexp: "`", Type: String, State: "#pop"},
+			{Regexp: `\$\{`, Type: Punctuation,
+				State: "templateExpression regexAllowed"},
+			{Regexp: "\\\\[\\s\\S]|[^`\\\\$]+|\\$", Type: String},
+		},
+		"templateExpression": {
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Include: "root"},
+		},
+	}
+
+	if len(types) > 0 {
+		// Unlike keywords, types are operands
+		states["expression"] = append([]RuleSpec{
+			{Regexp: `(?:` + strings.Join(types, "|") + `)\b`, Type: Keyword},
+		}, states["expression"]...)
+	}
+
+	if jsx {
+		// An element may appear wherever an operand is expected
+		states["regexAllowed"] = append([]RuleSpec{
+			{Regexp: `(<)(>)`, SubTypes: []TokenType{Punctuation, Punctuation},
+				State: "#pop jsxContent"},
+			{Regexp: `(<)([A-Za-z][\w.:-]*)`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "#pop jsxTag"},
+		}, states["regexAllowed"]...)
+		states["jsxTag"] = []RuleSpec{
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `/>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `>`, Type: Punctuation, State: "#pop jsxContent"},
+			{Regexp: `[\w:-]+`, Type: Attribute},
+			{Regexp: `=`, Type: Assignment},
+			{Regexp: `"[^"]*"|'[^']*'`, Type: String},
+			{Regexp: `\{`, Type: Punctuation,
+				State: "jsxExpression regexAllowed"},
+		}
+		states["jsxContent"] = []RuleSpec{
+			{Regexp: `(</)([\w.:-]*)(\s*)(>)`,
+				SubTypes: []TokenType{Punctuation, Tag, Whitespace,
+					Punctuation},
+				State: "#pop"},
+			{Regexp: `(<)(>)`, SubTypes: []TokenType{Punctuation, Punctuation},
+				State: "jsxContent"},
+			{Regexp: `(<)([A-Za-z][\w.:-]*)`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "jsxTag"},
+			{Regexp: `\{`, Type: Punctuation,
+				State: "jsxExpression regexAllowed"},
+			{Regexp: `&(?:[A-Za-z][A-Za-z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`,
+				Type: Literal},
+			{Regexp: `[^<{&]+|&`, Type: Text},
+		}
+		states["jsxExpression"] = []RuleSpec{
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Include: "root"},
+		}
+	}
+
+	return states
+}
+
+func init() {
+	Register(JavaScript.Name, JavaScript)
+	Register(JSX.Name, JSX)
+	Register(TypeScript.Name, TypeScript)
+	Register(TSX.Name, TSX)
+}
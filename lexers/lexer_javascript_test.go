@@ -0,0 +1,216 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerJavaScript(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		// Regular expressions and division
+		{"x = a / b / /c/g.test(d)", []Token{
+			{Value: "x", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "a", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "/", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "b", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "/", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "/c/g", Type: String},
+			{Value: ".", Type: Punctuation},
+			{Value: "test", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: "d", Type: Text},
+			{Value: ")", Type: Punctuation},
+		}},
+		{`return /[/]\//.source`, []Token{
+			{Value: "return", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: `/[/]\//`, Type: String},
+			{Value: ".", Type: Punctuation},
+			{Value: "source", Type: Attribute},
+		}},
+		// Numbers
+		{"1_000n, 0xFF, 0b1010, .5e-3, 1.", []Token{
+			{Value: "1_000n", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "0xFF", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "0b1010", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: ".5e-3", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "1.", Type: Number},
+		}},
+		// Template literals, including nested templates and braces
+		{"`a ${b + `c${ {d: 1}.d }`} \\` e`", []Token{
+			{Value: "`", Type: String},
+			{Value: "a ", Type: String},
+			{Value: "${", Type: Punctuation},
+			{Value: "b", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "+", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "`", Type: String},
+			{Value: "c", Type: String},
+			{Value: "${", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: "d", Type: Text},
+			{Value: ":", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "1", Type: Number},
+			{Value: "}", Type: Punctuation},
+			{Value: ".", Type: Punctuation},
+			{Value: "d", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "}", Type: Punctuation},
+			{Value: "`", Type: String},
+			{Value: "}", Type: Punctuation},
+			{Value: " ", Type: String},
+			{Value: "\\`", Type: String},
+			{Value: " e", Type: String},
+			{Value: "`", Type: String},
+		}},
+		// Keywords, literals and comments
+		{"if (this.x === null) // no\n/* a */ y?.z", []Token{
+			{Value: "if", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "(", Type: Punctuation},
+			{Value: "this", Type: Keyword},
+			{Value: ".", Type: Punctuation},
+			{Value: "x", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "===", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "null", Type: Literal},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "// no", Type: Comment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "/*", Type: Comment},
+			{Value: " a ", Type: Comment},
+			{Value: "*/", Type: Comment},
+			{Value: " ", Type: Whitespace},
+			{Value: "y", Type: Text},
+			{Value: "?.", Type: Punctuation},
+			{Value: "z", Type: Attribute},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.JavaScript,
+			item.Subject), item.Subject)
+	}
+}
+
+func TestLexerTypeScript(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "let", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "a", Type: Text},
+		{Value: ":", Type: Operator},
+		{Value: " ", Type: Whitespace},
+		{Value: "string", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "=", Type: Assignment},
+		{Value: " ", Type: Whitespace},
+		{Value: "b", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "as", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "any", Type: Keyword},
+	}, tokenize(t, lexers.TypeScript, "let a: string = b as any"))
+
+	// Types are operands, so may be followed by a division
+	assert.Equal(t, []Token{
+		{Value: "x", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "as", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "number", Type: Keyword},
+		{Value: " ", Type: Whitespace},
+		{Value: "/", Type: Operator},
+		{Value: " ", Type: Whitespace},
+		{Value: "2", Type: Number},
+		{Value: " ", Type: Whitespace},
+		{Value: "/", Type: Operator},
+		{Value: " ", Type: Whitespace},
+		{Value: "y", Type: Text},
+	}, tokenize(t, lexers.TypeScript, "x as number / 2 / y"))
+
+	// TypeScript keywords aren't reserved in JavaScript
+	assert.Equal(t, []Token{
+		{Value: "type", Type: Text},
+	}, tokenize(t, lexers.JavaScript, "type"))
+}
+
+func TestLexerJSX(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "x", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "=", Type: Assignment},
+		{Value: " ", Type: Whitespace},
+		{Value: "<", Type: Punctuation},
+		{Value: "A.B", Type: Tag},
+		{Value: " ", Type: Whitespace},
+		{Value: "c", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: `"d"`, Type: String},
+		{Value: " ", Type: Whitespace},
+		{Value: "e", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "{", Type: Punctuation},
+		{Value: "f", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "<", Type: Operator},
+		{Value: " ", Type: Whitespace},
+		{Value: "1", Type: Number},
+		{Value: "}", Type: Punctuation},
+		{Value: ">", Type: Punctuation},
+		{Value: "Hi ", Type: Text},
+		{Value: "{", Type: Punctuation},
+		{Value: "name", Type: Text},
+		{Value: "}", Type: Punctuation},
+		{Value: "<", Type: Punctuation},
+		{Value: "br", Type: Tag},
+		{Value: "/>", Type: Punctuation},
+		{Value: "</", Type: Punctuation},
+		{Value: "A.B", Type: Tag},
+		{Value: ">", Type: Punctuation},
+		{Value: ";", Type: Punctuation},
+		{Value: " ", Type: Whitespace},
+		{Value: "a", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "<", Type: Operator},
+		{Value: " ", Type: Whitespace},
+		{Value: "b", Type: Text},
+	}, tokenize(t, lexers.JSX, `x = <A.B c="d" e={f < 1}>Hi {name}<br/></A.B>; a < b`))
+}
+
+func TestLexerHTMLScript(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "<", Type: Punctuation},
+		{Value: "script", Type: Tag},
+		{Value: ">", Type: Punctuation},
+		{Value: "a", Type: Text},
+		{Value: "<", Type: Operator},
+		{Value: "b", Type: Text},
+		{Value: "</", Type: Punctuation},
+		{Value: "script", Type: Tag},
+		{Value: ">", Type: Punctuation},
+	}, tokenize(t, lexers.HTML, "<script>a<b</script>"))
+}
@@ -0,0 +1,115 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// jinjaStates are the states of the Jinja lexers.
+var jinjaStates = StatesSpec{
+	"root": {
+		{Regexp: `\{#`, Type: Comment, State: "comment"},
+		// Raw blocks, whose contents are left as text, e.g.
+		// `{% raw %}{{ x }}{% endraw %}`
+		{Regexp: `(\{%[-+]?)(\s*)(raw|verbatim)(\s*)([-+]?%\})`,
+			SubTypes: []TokenType{Punctuation, Whitespace, Keyword,
+				Whitespace, Punctuation},
+			State: "raw"},
+		{Regexp: `\{%[-+]?`, Type: Punctuation, State: "statement"},
+		{Regexp: `\{\{[-+]?`, Type: Punctuation, State: "expression"},
+		{Regexp: `[^{]+|\{`, Type: Text},
+	},
+	"comment": {
+		{Regexp: `#\}`, Type: Comment, State: "#pop"},
+		{Regexp: `[^#]+|#`, Type: Comment},
+	},
+	"raw": {
+		{Regexp: `(\{%[-+]?)(\s*)(endraw|endverbatim)(\s*)([-+]?%\})`,
+			SubTypes: []TokenType{Punctuation, Whitespace, Keyword,
+				Whitespace, Punctuation},
+			State: "#pop"},
+		{Regexp: `(?:[^{]|\{[^%])+|\{`, Type: Text},
+	},
+	// statement matches the contents of `{% %}`, e.g. `{% for x in xs %}`
+	"statement": {
+		{Regexp: `[-+]?%\}`, Type: Punctuation, State: "#pop"},
+		{Include: "code"},
+	},
+	// expression matches the contents of `{{ }}`, e.g. `{{ x|title }}`
+	"expression": {
+		{Regexp: `[-+]?\}\}`, Type: Punctuation, State: "#pop"},
+		{Include: "code"},
+	},
+	"code": {
+		{Regexp: `\s+`, Type: Whitespace},
+		{Regexp: `"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`, Type: String},
+		{Regexp: `[0-9](?:_?[0-9])*(?:\.[0-9](?:_?[0-9])*)?` +
+			`(?:[eE][-+]?[0-9]+)?`,
+			Type: Number},
+		// Filters and tests, e.g. `|default("x")` or `is defined`
+		{Regexp: `(\|)(\s*)([\pL_][\pL\pN_]*)`,
+			SubTypes: []TokenType{Operator, Whitespace, Attribute}},
+		{Regexp: `(is)(\s+)(?:(not)(\s+))?([\pL_][\pL\pN_]*)`,
+			SubTypes: []TokenType{Keyword, Whitespace, Keyword, Whitespace,
+				Attribute}},
+		{Regexp: `(\.)([\pL_][\pL\pN_]*)`,
+			SubTypes: []TokenType{Punctuation, Attribute}},
+		{Regexp: `(?:true|false|none|True|False|None)\b`, Type: Literal},
+		{Regexp: `(?:and|as|autoescape|block|break|call|continue|context|` +
+			`cycle|do|elif|else|empty|endautoescape|endblock|endcall|` +
+			`endfilter|endfor|endif|endmacro|endset|endspaceless|endtrans|` +
+			`endwith|extends|filter|firstof|for|from|if|ifchanged|ignore|` +
+			`import|in|include|is|load|macro|missing|not|or|pluralize|` +
+			`recursive|set|spaceless|trans|url|with|without)\b`,
+			Type: Keyword},
+		{Regexp: `[\pL_][\pL\pN_]*`, Type: Text},
+		{Regexp: `==|!=|<=|>=|\*\*|//|[-+*/%~<>]`, Type: Operator},
+		{Regexp: `=`, Type: Assignment},
+		{Regexp: `[()\[\]{},:|]`, Type: Punctuation},
+	},
+}
+
+// NewJinja returns a Lexer for Jinja and Django templates, in which the
+// text surrounding each tag is tokenized by host, e.g. HTML. If host is
+// nil, the text is emitted as Text.
+func NewJinja(host Tokenizer) Lexer {
+	lexer := Lexer{
+		Name:      "jinja",
+		Aliases:   []string{"jinja2", "j2", "django", "djangotemplate"},
+		MimeTypes: []string{"text/x-jinja", "text/x-django"},
+		Filenames: []string{"*.j2", "*.jinja", "*.jinja2"},
+		States:    jinjaStates,
+		Filters:   []Filter{RemoveEmptiesFilter},
+	}
+	if host != nil {
+		lexer.Filters = append(lexer.Filters,
+			templateHostFilter(host, "root", "raw"))
+	}
+	return lexer
+}
+
+var (
+	// Jinja tokenizes Jinja templates, leaving the text surrounding each tag
+	// as Text.
+	Jinja = NewJinja(nil)
+	// JinjaHTML tokenizes Jinja templates producing HTML.
+	JinjaHTML = Lexer{
+		Name:    "jinja-html",
+		Aliases: []string{"html+jinja", "html+django"},
+		States:  jinjaStates,
+		Filters: []Filter{RemoveEmptiesFilter,
+			templateHostFilter(HTML, "root", "raw")},
+	}
+	// JinjaYAML tokenizes Jinja templates producing YAML, such as Ansible
+	// templates.
+	JinjaYAML = Lexer{
+		Name:    "jinja-yaml",
+		Aliases: []string{"yaml+jinja"},
+		States:  jinjaStates,
+		Filters: []Filter{RemoveEmptiesFilter,
+			templateHostFilter(YAML, "root", "raw")},
+	}
+)
+
+func init() {
+	Register(Jinja.Name, Jinja)
+	Register(JinjaHTML.Name, JinjaHTML)
+	Register(JinjaYAML.Name, JinjaYAML)
+}
@@ -0,0 +1,99 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerJinja(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"{%- for x in xs if x is not none %}", []Token{
+			{Value: "{%-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "for", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "x", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "in", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "xs", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "if", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "x", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "is", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "not", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "none", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "%}", Type: Punctuation},
+		}},
+		{`Hi {{ user.name|default("you") }}{# c #}`, []Token{
+			{Value: "Hi ", Type: Text},
+			{Value: "{{", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "user", Type: Text},
+			{Value: ".", Type: Punctuation},
+			{Value: "name", Type: Attribute},
+			{Value: "|", Type: Operator},
+			{Value: "default", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: `"you"`, Type: String},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "}}", Type: Punctuation},
+			{Value: "{#", Type: Comment},
+			{Value: " c ", Type: Comment},
+			{Value: "#}", Type: Comment},
+		}},
+		{"{% set n = 1 %}{% raw %}{{ x }}{% endraw %}", []Token{
+			{Value: "{%", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "set", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "n", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "1", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "%}", Type: Punctuation},
+			{Value: "{%", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "raw", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "%}", Type: Punctuation},
+			{Value: "{{ x }}", Type: Text},
+			{Value: "{%", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "endraw", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "%}", Type: Punctuation},
+		}},
+	} {
+		assert.Equal(t, item.Tokens,
+			tokenize(t, lexers.Jinja, item.Subject), item.Subject)
+	}
+}
+
+func TestLexerJinjaHost(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "<", Type: Punctuation},
+		{Value: "p", Type: Tag},
+		{Value: ">", Type: Punctuation},
+		{Value: "{{", Type: Punctuation},
+		{Value: "x", Type: Text},
+		{Value: "}}", Type: Punctuation},
+		{Value: "</", Type: Punctuation},
+		{Value: "p", Type: Tag},
+		{Value: ">", Type: Punctuation},
+	}, tokenize(t, lexers.JinjaHTML, "<p>{{x}}</p>"))
+}
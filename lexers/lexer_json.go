@@ -1,8 +1,10 @@
 package lexers
 
 import (
-	. "github.com/johnsto/go-highlight"
+	"sort"
 	"strings"
+
+	. "github.com/johnsto/go-highlight"
 )
 
 var JSON = Lexer{
@@ -84,80 +86,491 @@ var JSON = Lexer{
 		RemoveEmptiesFilter,
 	},
 	Formatter: &JSONFormatter{Indent: "  "},
+	Minifier:  JSONMinifier,
+	Validator: &JSONValidator{},
 }
 
+// jsonStates returns a copy of the JSON lexer's states, with the given
+// states added or replaced. This allows JSON variants to share the rules
+// describing the structure of a document.
+func jsonStates(overrides StatesSpec) StatesSpec {
+	states := StatesSpec{}
+	for name, rules := range JSON.States.(StatesSpec) {
+		states[name] = rules
+	}
+	for name, rules := range overrides {
+		states[name] = rules
+	}
+	return states
+}
+
+// JSONMinifier removes all whitespace and comments between JSON tokens.
+// Whitespace within strings is part of the String token, so is left
+// untouched.
+var JSONMinifier = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		return func(t Token) error {
+			if t.Type == Whitespace || t.Type == Comment {
+				return nil
+			}
+			return out(t)
+		}
+	})
+
 // JSONFormatter consumes a series of JSON tokens and emits additional tokens
 // to produce indented, formatted output.
+//
+// Tokens are written as they are read, except that an array or object is
+// buffered while its layout depends on contents yet to be read: until it
+// is closed if its keys are sorted, or until it is too long to fit within
+// Width.
 type JSONFormatter struct {
+	// Indent is the string used for each level of indentation, e.g. "  "
+	// or "\t".
 	Indent string
+	// Width, if positive, allows an array or object to be written on a
+	// single line if, including indentation, it fits within this many
+	// columns.
+	Width int
+	// TabWidth is the number of columns each tab of Indent occupies when
+	// measuring against Width. Defaults to 8.
+	TabWidth int
+	// ExpandEmpty writes empty arrays and objects across two lines, rather
+	// than as `[]` and `{}`.
+	ExpandEmpty bool
+	// SortKeys orders the members of each object by key.
+	SortKeys bool
+	// TrailingNewline writes a newline after the final value.
+	TrailingNewline bool
+	// Lines treats each line of input as a separate document, as in
+	// NDJSON, so that a malformed line does not affect those following.
+	Lines bool
+}
+
+// jsonNode is a buffered JSON value.
+type jsonNode struct {
+	// Key contains the key and assignment tokens of an object member.
+	Key []Token
+	// Value contains the tokens of a scalar value, or the opening bracket
+	// of an array or object.
+	Value []Token
+	// Close contains the closing bracket of an array or object, if seen.
+	Close []Token
+	// Children contains the elements of an array or object.
+	Children []*jsonNode
+	// Container is set if this is an array or object.
+	Container bool
+	// Comments contains any comments preceding the value.
+	Comments []Token
+	// Trailing contains any comments preceding the closing bracket.
+	Trailing []Token
+	// Started is set once the node has been written up to its open child,
+	// if any, after which its children are written as they are read
+	// rather than buffered.
+	Started bool
+	// Written counts the children of a started node.
+	Written int
+}
+
+// name returns the unquoted key of the node, for sorting.
+func (n *jsonNode) name() string {
+	s := ""
+	for _, t := range n.Key {
+		if t.Type != Punctuation && t.Type != Assignment {
+			s += t.Value
+		}
+	}
+	return s
+}
+
+// commented returns true if the node contains any comments, and so can't
+// be written on a single line.
+func (n *jsonNode) commented() bool {
+	if len(n.Trailing) > 0 {
+		return true
+	}
+	for _, c := range n.Children {
+		if len(c.Comments) > 0 || c.commented() {
+			return true
+		}
+	}
+	return false
+}
+
+// compactLen returns the length of the node when written on a single line.
+func (n *jsonNode) compactLen() int {
+	l := 0
+	for _, ts := range [][]Token{n.Key, n.Value, n.Close} {
+		for _, t := range ts {
+			l += len(t.Value)
+		}
+	}
+	if len(n.Key) > 0 {
+		l++ // space after assignment
+	}
+	for i, c := range n.Children {
+		if i > 0 {
+			l += 2 // comma and space
+		}
+		l += c.compactLen()
+	}
+	return l
 }
 
 func (f *JSONFormatter) Filter(emit func(Token) error) func(
 	Token) error {
 
-	// indents records the current indentation level
-	indents := 0
+	// stack holds the containers currently open; a prefix of these have
+	// been started, and the remainder are buffered within the first
+	// container that has not
+	var stack []*jsonNode
+	// key holds the tokens of the current object member's key
+	var key []Token
+	// str holds the tokens of the string currently being read
+	var str []Token
+	// comments holds any comments preceding the next value
+	var comments []Token
+	// written is set once the first top-level value has been written
+	written := false
 
-	return func(token Token) error {
-		indent := strings.Repeat(f.Indent, indents)
+	// begin separates each top-level value from the last
+	begin := func() error {
+		if written {
+			if err := emit(Token{Type: Whitespace, Value: "\n"}); err != nil {
+				return err
+			}
+		}
+		written = true
+		return nil
+	}
+
+	// separate begins the next child of the started node at the given
+	// depth
+	separate := func(n *jsonNode, depth int) error {
+		if n.Written > 0 {
+			if err := emit(Token{Type: Punctuation, Value: ","}); err != nil {
+				return err
+			}
+		}
+		n.Written++
+		return f.newline(depth+1, emit)
+	}
+
+	// advance starts each buffered container whose layout is now known,
+	// writing its children so far.
+	advance := func() error {
+		for depth, n := range stack {
+			if n.Started {
+				continue
+			} else if !f.expanded(n, depth) {
+				return nil
+			}
+			if depth == 0 {
+				if err := begin(); err != nil {
+					return err
+				}
+			}
+			if err := f.head(n, depth, emit); err != nil {
+				return err
+			}
+			n.Started = true
+			children := n.Children
+			n.Children = nil
+			for _, c := range children {
+				if err := separate(n, depth); err != nil {
+					return err
+				}
+				if depth+1 < len(stack) && c == stack[depth+1] {
+					// The open child is written as it is read
+					break
+				}
+				if err := f.write(c, depth+1, false, emit); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	// add attaches a new node to its parent, or writes it out if it is a
+	// complete top-level value.
+	add := func(n *jsonNode) error {
+		n.Key, key = key, nil
+		n.Comments, comments = comments, nil
+		depth := len(stack)
+		if depth == 0 && !n.Container {
+			if err := begin(); err != nil {
+				return err
+			}
+			return f.write(n, 0, false, emit)
+		} else if depth > 0 {
+			top := stack[depth-1]
+			if !top.Started {
+				top.Children = append(top.Children, n)
+			} else if err := separate(top, depth-1); err != nil {
+				return err
+			} else if !n.Container {
+				return f.write(n, depth, false, emit)
+			}
+		}
+		if n.Container {
+			stack = append(stack, n)
+		}
+		return advance()
+	}
+
+	// finish ends the innermost container, writing it out if it is no
+	// longer buffered.
+	finish := func() error {
+		depth := len(stack) - 1
+		n := stack[depth]
+		stack = stack[:depth]
+		switch {
+		case n.Started:
+			return f.close(n, depth, false, emit)
+		case depth == 0:
+			if err := begin(); err != nil {
+				return err
+			}
+			return f.write(n, 0, false, emit)
+		case stack[depth-1].Started:
+			return f.write(n, depth, false, emit)
+		}
+		return advance()
+	}
+
+	// end flushes any incomplete value
+	end := func() error {
+		if str != nil {
+			ts := str
+			str = nil
+			if err := add(&jsonNode{Value: ts}); err != nil {
+				return err
+			}
+		}
+		key = nil
+		for len(stack) > 0 {
+			if err := finish(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
-		// temporary storage for the tokens to emit
-		var out []Token
+	return func(token Token) error {
+		if str != nil {
+			// Reading a string; wait for the closing quote
+			str = append(str, token)
+			if token.Type != Punctuation || token.Value != str[0].Value {
+				return nil
+			}
+			ts := str
+			str = nil
+			if token.State == "objectKey" {
+				key = append(key, ts...)
+				return nil
+			}
+			return add(&jsonNode{Value: ts})
+		}
 
 		switch token.Type {
 		case Whitespace:
 			// nah, we'll add our own whitespace, thanks!
+			if f.Lines && strings.Contains(token.Value, "\n") {
+				return end()
+			}
 			return nil
-		case Assignment:
-			switch token.Value {
-			case ":":
-				out = []Token{token, Token{Type: Whitespace, Value: " "}}
-			default:
-				out = []Token{token}
+		case Comment:
+			if n := len(comments); n > 0 &&
+				strings.HasPrefix(comments[n-1].Value, "/*") &&
+				!strings.HasSuffix(comments[n-1].Value, "*/") {
+				// Continuation of a multi-line comment
+				comments[n-1].Value += token.Value
+				return nil
 			}
+			comments = append(comments, token)
+			return nil
+		case Attribute, Assignment:
+			key = append(key, token)
+			return nil
 		case Punctuation:
 			switch token.Value {
 			case ",":
-				out = []Token{token,
-					Token{Type: Whitespace, Value: "\n"},
-					Token{Type: Whitespace, Value: indent}}
-			case "{":
-				fallthrough
-			case "[":
-				out = append(out, token)
-				out = append(out, Token{Type: Whitespace, Value: "\n"})
-				indents++
-				indent = strings.Repeat(f.Indent, indents)
-				out = append(out, Token{Type: Whitespace, Value: indent})
-			case "}":
-				fallthrough
-			case "]":
-				out = append(out, Token{Type: Whitespace, Value: "\n"})
-				indents--
-				indent = strings.Repeat(f.Indent, indents)
-				out = append(out, Token{Type: Whitespace, Value: indent})
-				out = append(out, token)
-			case "\"":
-				out = []Token{token}
-			default:
-				out = []Token{token}
+				// separators are written as required
+				return nil
+			case `"`, "'":
+				str = []Token{token}
+				return nil
+			case "{", "[":
+				return add(&jsonNode{Value: []Token{token},
+					Container: true})
+			case "}", "]":
+				if len(stack) == 0 {
+					// Unbalanced; write as-is
+					break
+				}
+				n := stack[len(stack)-1]
+				n.Close = []Token{token}
+				n.Trailing, comments = comments, nil
+				return finish()
 			}
 		case "":
-			// EOF
-			break
-		default:
-			out = []Token{token}
+			// EOF; flush anything left over
+			if err := end(); err != nil {
+				return err
+			}
+			for _, c := range comments {
+				if err := begin(); err != nil {
+					return err
+				}
+				if err := emit(c); err != nil {
+					return err
+				}
+			}
+			if written && f.TrailingNewline {
+				return emit(Token{Type: Whitespace, Value: "\n"})
+			}
+			return nil
 		}
 
-		// Attempt to emit each token, failing on first error
-		for _, t := range out {
-			if err := emit(t); err != nil {
+		return add(&jsonNode{Value: []Token{token}})
+	}
+}
+
+// expanded returns true if the given container, which may not yet be
+// complete, is known to be written across several lines at the given depth
+// without being sorted.
+func (f *JSONFormatter) expanded(n *jsonNode, depth int) bool {
+	if len(n.Children) == 0 || (f.SortKeys && n.Value[0].Value == "{") {
+		return false
+	}
+	// Allow for a trailing comma when determining the fit
+	return f.Width <= 0 || n.commented() ||
+		f.indentWidth(depth)+n.compactLen()+1 > f.Width
+}
+
+// indentWidth returns the number of columns occupied by the indentation
+// for the given depth.
+func (f *JSONFormatter) indentWidth(depth int) int {
+	tabWidth := f.TabWidth
+	if tabWidth <= 0 {
+		tabWidth = 8
+	}
+	n := len(f.Indent) + strings.Count(f.Indent, "\t")*(tabWidth-1)
+	return n * depth
+}
+
+// head emits any comments preceding the given node, its key, and its value
+// or opening bracket.
+func (f *JSONFormatter) head(n *jsonNode, depth int,
+	emit func(Token) error) error {
+	for _, t := range n.Comments {
+		if err := emit(t); err != nil {
+			return err
+		}
+		if err := f.newline(depth, emit); err != nil {
+			return err
+		}
+	}
+	for _, t := range n.Key {
+		if err := emit(t); err != nil {
+			return err
+		}
+		if t.Type == Assignment {
+			if err := emit(Token{Type: Whitespace, Value: " "}); err != nil {
 				return err
 			}
 		}
+	}
+	return emitAll(emit, n.Value...)
+}
 
+// write emits the given node, and any children, at the given depth. If
+// compact is set, the node is written on a single line.
+func (f *JSONFormatter) write(n *jsonNode, depth int, compact bool,
+	emit func(Token) error) error {
+	if err := f.head(n, depth, emit); err != nil {
+		return err
+	}
+	if !n.Container {
+		return nil
+	}
+
+	children := n.Children
+	if f.SortKeys && n.Value[0].Value == "{" {
+		children = append([]*jsonNode{}, children...)
+		sort.SliceStable(children, func(i, j int) bool {
+			return children[i].name() < children[j].name()
+		})
+	}
+
+	if !compact && !n.commented() {
+		// Allow for a trailing comma when determining the fit
+		compact = (len(children) == 0 && !f.ExpandEmpty) || (f.Width > 0 &&
+			f.indentWidth(depth)+n.compactLen()+1 <= f.Width)
+	}
+
+	for i, c := range children {
+		if i > 0 {
+			if err := emit(Token{Type: Punctuation, Value: ","}); err != nil {
+				return err
+			}
+		}
+		if !compact {
+			if err := f.newline(depth+1, emit); err != nil {
+				return err
+			}
+		} else if i > 0 {
+			if err := emit(Token{Type: Whitespace, Value: " "}); err != nil {
+				return err
+			}
+		}
+		if err := f.write(c, depth+1, compact, emit); err != nil {
+			return err
+		}
+	}
+	return f.close(n, depth, compact, emit)
+}
+
+// close emits any comments preceding the closing bracket of the given
+// node, followed by the bracket itself, if any.
+func (f *JSONFormatter) close(n *jsonNode, depth int, compact bool,
+	emit func(Token) error) error {
+	for _, t := range n.Trailing {
+		if err := f.newline(depth+1, emit); err != nil {
+			return err
+		}
+		if err := emit(t); err != nil {
+			return err
+		}
+	}
+
+	if n.Close == nil {
+		// Unterminated container
 		return nil
 	}
+	if !compact {
+		if err := f.newline(depth, emit); err != nil {
+			return err
+		}
+	}
+	return emitAll(emit, n.Close...)
+}
+
+// newline emits a newline, followed by indentation for the given depth.
+func (f *JSONFormatter) newline(depth int, emit func(Token) error) error {
+	return emitAll(emit,
+		Token{Type: Whitespace, Value: "\n"},
+		Token{Type: Whitespace, Value: strings.Repeat(f.Indent, depth)})
+}
+
+// emitAll emits each of the given tokens in turn, failing on first error.
+func emitAll(emit func(Token) error, tokens ...Token) error {
+	for _, t := range tokens {
+		if err := emit(t); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
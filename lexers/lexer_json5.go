@@ -0,0 +1,65 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// JSON5 extends JSON with comments, unquoted keys, single-quoted strings,
+// hexadecimal numbers, Infinity/NaN and trailing commas.
+var JSON5 = Lexer{
+	Name:      "json5",
+	MimeTypes: []string{"application/json5"},
+	Filenames: []string{"*.json5"},
+	States: jsonStates(StatesSpec{
+		"whitespace":       jsonCommentStates["whitespace"],
+		"multiLineComment": jsonCommentStates["multiLineComment"],
+		// number matches a JSON5 number
+		"number": {
+			// +0xC0FFEE
+			{Regexp: `[\+\-]?0[xX][0-9a-fA-F]+`, Type: Number},
+			// -Infinity, NaN
+			{Regexp: `[\+\-]?(Infinity|NaN)`, Type: Number},
+			// +.123e-4, 5.
+			{Regexp: `[\+\-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][\+\-]?[0-9]+)?`,
+				Type: Number},
+		},
+		// string matches a double or single-quoted string
+		"string": {
+			{Regexp: `(")(")`,
+				SubTypes: []TokenType{Punctuation, Punctuation}},
+			{Regexp: `(")((?:\\\"|[^\"])*?)(")`,
+				SubTypes: []TokenType{Punctuation, String, Punctuation}},
+			{Regexp: `(')(')`,
+				SubTypes: []TokenType{Punctuation, Punctuation}},
+			{Regexp: `(')((?:\\'|[^'])*?)(')`,
+				SubTypes: []TokenType{Punctuation, String, Punctuation}},
+		},
+		// objectKey additionally matches single-quoted and unquoted keys
+		"objectKey": {
+			{Include: "whitespace"},
+			{Regexp: `(")((?:\\\"|[^\"])*?)(")(\s*)(:)`,
+				SubTypes: []TokenType{Punctuation, Attribute, Punctuation,
+					Whitespace, Assignment},
+				State: "objectValue"},
+			{Regexp: `(')((?:\\'|[^'])*?)(')(\s*)(:)`,
+				SubTypes: []TokenType{Punctuation, Attribute, Punctuation,
+					Whitespace, Assignment},
+				State: "objectValue"},
+			{Regexp: `([A-Za-z_$][A-Za-z0-9_$]*)(\s*)(:)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment},
+				State:    "objectValue"},
+			{Regexp: "}", Type: Punctuation, State: "#pop"},
+		},
+	}),
+	Filters: []Filter{
+		RemoveEmptiesFilter,
+	},
+	Formatter: &JSONFormatter{Indent: "  "},
+	Minifier:  JSONMinifier,
+	Validator: &JSONValidator{
+		AllowComments:       true,
+		AllowTrailingCommas: true,
+	},
+}
+
+func init() {
+	Register(JSON5.Name, JSON5)
+}
@@ -0,0 +1,63 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerJSON5(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"{a: 1}", []Token{
+			{Value: "{", Type: Punctuation},
+			{Value: "a", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "1", Type: Number},
+			{Value: "}", Type: Punctuation},
+		}},
+		{"{'a b': 'c'}", []Token{
+			{Value: "{", Type: Punctuation},
+			{Value: "'", Type: Punctuation},
+			{Value: "a b", Type: Attribute},
+			{Value: "'", Type: Punctuation},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "'", Type: Punctuation},
+			{Value: "c", Type: String},
+			{Value: "'", Type: Punctuation},
+			{Value: "}", Type: Punctuation},
+		}},
+		{"[0xFF, +.5, 5., -Infinity, NaN,]", []Token{
+			{Value: "[", Type: Punctuation},
+			{Value: "0xFF", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "+.5", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "5.", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "-Infinity", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "NaN", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: "]", Type: Punctuation},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.JSON5, item.Subject),
+			item.Subject)
+		assert.Equal(t, item.Subject,
+			render(t, lexers.JSON5.Validate, item.Subject))
+	}
+
+	assert.Equal(t, "{\n  a: 'x',\n  // y\n  b: [\n    0x1\n  ]\n}",
+		render(t, lexers.JSON5.Format, "{a: 'x', // y\nb: [0x1,],}"))
+}
@@ -0,0 +1,138 @@
+package lexers
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// jsonIdentifier matches object keys that can be written in dot notation.
+var jsonIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// jsonPathFrame records the path of an open array or object.
+type jsonPathFrame struct {
+	// path is the path of the container itself
+	path string
+	// array is set if the container is an array
+	array bool
+	// index is the index of the current element within an array
+	index int
+	// member is the path of the current member within an object, or empty
+	// between members
+	member string
+}
+
+// JSONPathFilter annotates each JSON token with the path of the value it
+// belongs to, in JSONPath notation. For example, in `{"items": [1, 2]}` the
+// token `2` is given the path `$.items[1]`. Object keys share the path of
+// their value, while brackets and separators are given the path of the
+// array or object they belong to.
+var JSONPathFilter = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		var stack []*jsonPathFrame
+		// key holds the tokens of an object key until it is complete
+		var key []Token
+		// quote holds the opening quote of the current string, if any
+		quote := ""
+
+		// path returns the path of the current value
+		path := func() string {
+			if len(stack) == 0 {
+				return "$"
+			}
+			f := stack[len(stack)-1]
+			if f.array {
+				return fmt.Sprintf("%s[%d]", f.path, f.index)
+			} else if f.member != "" {
+				return f.member
+			}
+			return f.path
+		}
+
+		return func(t Token) error {
+			if t.Type == "" {
+				if err := emitAll(out, key...); err != nil {
+					return err
+				}
+				return out(t)
+			}
+
+			if key != nil {
+				// Reading a quoted key; wait for the closing quote
+				key = append(key, t)
+				if t.Type != Punctuation || t.Value != quote {
+					return nil
+				}
+				quote = ""
+			} else if quote != "" {
+				// Reading a string value
+				if t.Type == Punctuation && t.Value == quote {
+					quote = ""
+				}
+			} else if t.State == "objectKey" && t.Type == Punctuation &&
+				(t.Value == `"` || t.Value == "'") {
+				key, quote = []Token{t}, t.Value
+				return nil
+			} else if t.Type == Attribute {
+				// Unquoted key
+				key = []Token{t}
+			} else if t.Type == Punctuation && (t.Value == `"` ||
+				t.Value == "'") {
+				quote = t.Value
+			}
+
+			if key != nil {
+				// Key complete; resolve the path of its member
+				name := ""
+				for _, k := range key {
+					if k.Type == Attribute {
+						name += k.Value
+					}
+				}
+				ts := key
+				key = nil
+				if len(stack) > 0 && !stack[len(stack)-1].array {
+					f := stack[len(stack)-1]
+					if jsonIdentifier.MatchString(name) {
+						f.member = f.path + "." + name
+					} else {
+						f.member = fmt.Sprintf(`%s["%s"]`, f.path, name)
+					}
+				}
+				for _, k := range ts {
+					k.Path = path()
+					if err := out(k); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			t.Path = path()
+
+			if quote == "" && t.Type == Punctuation {
+				switch t.Value {
+				case "{", "[":
+					stack = append(stack, &jsonPathFrame{
+						path:  t.Path,
+						array: t.Value == "[",
+					})
+				case "}", "]":
+					if len(stack) > 0 {
+						t.Path = stack[len(stack)-1].path
+						stack = stack[:len(stack)-1]
+					}
+				case ",":
+					if len(stack) > 0 {
+						f := stack[len(stack)-1]
+						t.Path = f.path
+						f.index++
+						f.member = ""
+					}
+				}
+			}
+
+			return out(t)
+		}
+	})
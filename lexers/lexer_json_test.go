@@ -1,8 +1,10 @@
 package lexers_test
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	. "github.com/johnsto/go-highlight"
@@ -245,3 +247,161 @@ func TestLexerJSONComplex(t *testing.T) {
 		}
 	}
 }
+
+func TestJSONFormatter(t *testing.T) {
+	for _, item := range []struct {
+		Name      string
+		Formatter lexers.JSONFormatter
+		Subject   string
+		Output    string
+	}{{
+		Name:      "default",
+		Formatter: lexers.JSONFormatter{Indent: "  "},
+		Subject:   `{"a":[1,2],"b":{"c":null}}`,
+		Output: "{\n  \"a\": [\n    1,\n    2\n  ],\n" +
+			"  \"b\": {\n    \"c\": null\n  }\n}",
+	}, {
+		Name:      "empty",
+		Formatter: lexers.JSONFormatter{Indent: "  "},
+		Subject:   `{"a":[],"b":{ }}`,
+		Output:    "{\n  \"a\": [],\n  \"b\": {}\n}",
+	}, {
+		Name:      "expand empty",
+		Formatter: lexers.JSONFormatter{Indent: "  ", ExpandEmpty: true},
+		Subject:   `{"a":[]}`,
+		Output:    "{\n  \"a\": [\n  ]\n}",
+	}, {
+		Name:      "tabs",
+		Formatter: lexers.JSONFormatter{Indent: "\t"},
+		Subject:   `[[1]]`,
+		Output:    "[\n\t[\n\t\t1\n\t]\n]",
+	}, {
+		Name:      "compact",
+		Formatter: lexers.JSONFormatter{Indent: "  ", Width: 24},
+		Subject:   `{"short":[1,2,3],"long":["aaaaaaaa","bbbbbbbb"],"o":{"x":""}}`,
+		Output: "{\n  \"short\": [1, 2, 3],\n  \"long\": [\n" +
+			"    \"aaaaaaaa\",\n    \"bbbbbbbb\"\n  ],\n  \"o\": {\"x\": \"\"}\n}",
+	}, {
+		Name:      "tab width",
+		Formatter: lexers.JSONFormatter{Indent: "\t", Width: 10},
+		Subject:   `[[1,2],3]`,
+		Output:    "[\n\t[\n\t\t1,\n\t\t2\n\t],\n\t3\n]",
+	}, {
+		Name: "narrow tabs",
+		Formatter: lexers.JSONFormatter{Indent: "\t", Width: 10,
+			TabWidth: 2},
+		Subject: `[[1,2],3]`,
+		Output:  "[\n\t[1, 2],\n\t3\n]",
+	}, {
+		Name:      "sort keys",
+		Formatter: lexers.JSONFormatter{Indent: " ", SortKeys: true},
+		Subject:   `{"b":1,"a":{"d":2,"c":3},"":[{"z":0,"y":1}]}`,
+		Output: "{\n \"\": [\n  {\n   \"y\": 1,\n   \"z\": 0\n  }\n ],\n" +
+			" \"a\": {\n  \"c\": 3,\n  \"d\": 2\n },\n \"b\": 1\n}",
+	}, {
+		Name:      "trailing newline",
+		Formatter: lexers.JSONFormatter{TrailingNewline: true},
+		Subject:   `"a"`,
+		Output:    "\"a\"\n",
+	}, {
+		Name:      "multiple values",
+		Formatter: lexers.JSONFormatter{Indent: "  ", Width: 80},
+		Subject:   "{\"a\": 1}\n[2,\n3] 4",
+		Output:    "{\"a\": 1}\n[2, 3]\n4",
+	}, {
+		Name:      "unterminated",
+		Formatter: lexers.JSONFormatter{Indent: "  "},
+		Subject:   `{"a":[1`,
+		Output:    "{\n  \"a\": [\n    1",
+	}} {
+		lexer := lexers.JSON
+		lexer.Formatter = &item.Formatter
+		assert.Equal(t, item.Output, render(t, lexer.Format, item.Subject),
+			item.Name)
+	}
+}
+
+func TestJSONFormatterStreaming(t *testing.T) {
+	tokens, _ := lexers.JSON.TokenizeString(`{"a":[1,2],"b":{"c":[3`)
+	for _, item := range []struct {
+		Name      string
+		Formatter lexers.JSONFormatter
+		Output    string
+	}{{
+		// Values are written as they are read...
+		Name:      "default",
+		Formatter: lexers.JSONFormatter{Indent: "  "},
+		Output: "{\n  \"a\": [\n    1,\n    2\n  ],\n  \"b\": {\n" +
+			"    \"c\": [\n      3",
+	}, {
+		// ...unless their layout depends on what follows
+		Name:      "compact",
+		Formatter: lexers.JSONFormatter{Indent: "  ", Width: 16},
+		Output:    "{\n  \"a\": [1, 2],\n  ",
+	}, {
+		Name:      "sort keys",
+		Formatter: lexers.JSONFormatter{Indent: "  ", SortKeys: true},
+		Output:    "",
+	}} {
+		var b strings.Builder
+		emit := item.Formatter.Filter(func(t Token) error {
+			b.WriteString(t.Value)
+			return nil
+		})
+		for _, token := range tokens {
+			if token != EndToken {
+				assert.Nil(t, emit(token))
+			}
+		}
+		assert.Equal(t, item.Output, b.String(), item.Name)
+	}
+}
+
+func TestJSONPathFilter(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		// Paths maps significant token values to their expected path
+		Paths [][2]string
+	}{
+		{`1`, [][2]string{{"1", "$"}}},
+		{`[1,"a"]`, [][2]string{
+			{"[", "$"}, {"1", "$[0]"}, {",", "$"}, {`"`, "$[1]"},
+			{"a", "$[1]"}, {`"`, "$[1]"}, {"]", "$"},
+		}},
+		{`{"items": [{"name": null}, {"name": true}]}`, [][2]string{
+			{"{", "$"},
+			{`"`, "$.items"}, {"items", "$.items"}, {`"`, "$.items"},
+			{":", "$.items"}, {"[", "$.items"},
+			{"{", "$.items[0]"},
+			{`"`, "$.items[0].name"}, {"name", "$.items[0].name"},
+			{`"`, "$.items[0].name"}, {":", "$.items[0].name"},
+			{"null", "$.items[0].name"},
+			{"}", "$.items[0]"}, {",", "$.items"},
+			{"{", "$.items[1]"},
+			{`"`, "$.items[1].name"}, {"name", "$.items[1].name"},
+			{`"`, "$.items[1].name"}, {":", "$.items[1].name"},
+			{"true", "$.items[1].name"},
+			{"}", "$.items[1]"}, {"]", "$.items"}, {"}", "$"},
+		}},
+		{`{"a b": 1, "c": [[2]]}`, [][2]string{
+			{"{", "$"},
+			{`"`, `$["a b"]`}, {"a b", `$["a b"]`}, {`"`, `$["a b"]`},
+			{":", `$["a b"]`}, {"1", `$["a b"]`}, {",", "$"},
+			{`"`, "$.c"}, {"c", "$.c"}, {`"`, "$.c"}, {":", "$.c"},
+			{"[", "$.c"}, {"[", "$.c[0]"}, {"2", "$.c[0][0]"},
+			{"]", "$.c[0]"}, {"]", "$.c"}, {"}", "$"},
+		}},
+	} {
+		paths := [][2]string{}
+		filter := lexers.JSONPathFilter.Filter(func(t Token) error {
+			if t.Type != Whitespace && t.Type != "" {
+				paths = append(paths, [2]string{t.Value, t.Path})
+			}
+			return nil
+		})
+		err := lexers.JSON.Tokenize(
+			bufio.NewReader(strings.NewReader(item.Subject)), filter)
+		assert.Equal(t, io.EOF, err, item.Subject)
+		assert.Equal(t, item.Paths, paths, item.Subject)
+	}
+}
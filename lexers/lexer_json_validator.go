@@ -0,0 +1,292 @@
+package lexers
+
+import (
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// JSONValidator checks the structure of the tokens produced by the JSON
+// lexer. The lexer itself is lenient, and will happily tokenize documents
+// with missing or trailing commas and mismatched brackets; the validator
+// marks such tokens as Error and, at the end of input, returns a
+// ValidationErrors describing each problem.
+type JSONValidator struct {
+	// AllowComments permits comments between tokens.
+	AllowComments bool
+	// AllowTrailingCommas permits a comma after the last element of an
+	// array or object.
+	AllowTrailingCommas bool
+	// Lines expects each line of input to contain a separate document, as
+	// in NDJSON. Blank lines are permitted.
+	Lines bool
+}
+
+// jsonExpect describes what a JSONValidator expects to see next.
+type jsonExpect int
+
+const (
+	// expectValue expects any value, e.g. at the start of input or after a
+	// comma within an array.
+	expectValue jsonExpect = iota
+	// expectValueOrEnd expects a value or the end of an array.
+	expectValueOrEnd
+	// expectKey expects an object key, e.g. after a comma within an object.
+	expectKey
+	// expectKeyOrEnd expects an object key or the end of an object.
+	expectKeyOrEnd
+	// expectColon expects the colon following an object key.
+	expectColon
+	// expectNext expects a comma or the end of the current array or object.
+	expectNext
+	// expectEOF expects the end of input, after a complete value.
+	expectEOF
+)
+
+// jsonContainer returns the opening bracket of the array or object the
+// JSON lexer is within when in the given state, or 0 if it is in neither.
+func jsonContainer(state string) byte {
+	switch state {
+	case "objectKey", "objectValue":
+		return '{'
+	case "arrayValue":
+		return '['
+	}
+	return 0
+}
+
+// describe returns a human-readable description of what is expected, given
+// the opening bracket of the enclosing array or object, or 0 if unknown.
+func (e jsonExpect) describe(open byte) string {
+	switch e {
+	case expectValue:
+		return "a value"
+	case expectValueOrEnd:
+		return "a value or ']'"
+	case expectKey:
+		return "an object key"
+	case expectKeyOrEnd:
+		return "an object key or '}'"
+	case expectColon:
+		return "':'"
+	case expectNext:
+		switch open {
+		case '{':
+			return "',' or '}'"
+		case '[':
+			return "',' or ']'"
+		}
+		return "',' or a closing bracket"
+	}
+	return "end of input"
+}
+
+func (v *JSONValidator) Filter(out func(Token) error) func(Token) error {
+	// depth counts the arrays and objects enclosing the current token
+	depth := 0
+	// open is the opening bracket of the innermost of these, as given by
+	// the state of the lexer, or 0 if not known
+	var open byte
+	// expect describes the next significant token
+	expect := expectValue
+	// quote holds the opening quote of the current string, if any
+	quote := ""
+	// held contains a comma, and any tokens following it, that can't be
+	// emitted until it's known whether the comma is a trailing one
+	var held []Token
+	var heldPos Position
+	// pos is the position of the current token
+	var pos Position
+	var errs ValidationErrors
+
+	fail := func(t *Token, at Position, format string, args ...interface{}) {
+		t.Type = Error
+		errs = append(errs, at.Error(format, args...))
+	}
+
+	// next returns the expectation following a complete value
+	next := func() jsonExpect {
+		if depth == 0 {
+			return expectEOF
+		}
+		return expectNext
+	}
+
+	// check validates a significant token found at the given position,
+	// updating the expectation
+	check := func(t *Token, pos Position) {
+		switch {
+		case t.Type == Error:
+			fail(t, pos, "unexpected %q", t.Value)
+
+		case t.Type == Comment:
+			if !v.AllowComments {
+				fail(t, pos, "comments are not allowed")
+			}
+
+		case t.Type == Assignment:
+			if expect != expectColon {
+				fail(t, pos, "expected %s but found ':'",
+					expect.describe(open))
+				return
+			}
+			expect = expectValue
+
+		case t.Type == Punctuation && t.Value == ",":
+			if expect != expectNext {
+				fail(t, pos, "expected %s but found ','",
+					expect.describe(open))
+				return
+			}
+			if open == '{' {
+				expect = expectKey
+			} else {
+				expect = expectValue
+			}
+
+		case t.Type == Punctuation && (t.Value == "}" || t.Value == "]"):
+			want := byte('{')
+			if t.Value == "]" {
+				want = '['
+			}
+			switch {
+			case depth == 0:
+				fail(t, pos, "unexpected %q", t.Value)
+				return
+			case open != want:
+				fail(t, pos, "expected %s but found %q",
+					expect.describe(open), t.Value)
+			case expect == expectColon || expect == expectValue &&
+				held == nil:
+				fail(t, pos, "expected %s but found %q",
+					expect.describe(open), t.Value)
+			}
+			// The enclosing container is known from the next token
+			depth--
+			open = 0
+			expect = next()
+
+		case t.State == "objectKey" || t.Type == Attribute:
+			// Start of an object key
+			if expect != expectKey && expect != expectKeyOrEnd {
+				fail(t, pos, "expected %s but found object key",
+					expect.describe(open))
+				if expect != expectNext {
+					return
+				}
+			}
+			expect = expectColon
+
+		default:
+			// Start of a value; if a comma is missing, carry on as if
+			// it were present
+			if expect != expectValue && expect != expectValueOrEnd {
+				fail(t, pos, "expected %s but found %q",
+					expect.describe(open), t.Value)
+				if expect != expectNext {
+					return
+				}
+			}
+			expect = next()
+			if t.Type == Punctuation && (t.Value == "{" || t.Value == "[") {
+				depth++
+				open = t.Value[0]
+				if t.Value == "{" {
+					expect = expectKeyOrEnd
+				} else {
+					expect = expectValueOrEnd
+				}
+			}
+		}
+	}
+
+	return func(t Token) error {
+		if t.Type == "" {
+			// End of input
+			if err := emitAll(out, held...); err != nil {
+				return err
+			}
+			if expect != expectEOF && !(v.Lines && expect == expectValue &&
+				depth == 0) {
+				errs = append(errs, pos.Error(
+					"unexpected end of input, expected %s",
+					expect.describe(open)))
+			}
+			if err := out(t); err != nil {
+				return err
+			}
+			if len(errs) > 0 {
+				return errs
+			}
+			return nil
+		}
+
+		at := pos
+		pos.Advance(t)
+		if depth > 0 {
+			open = jsonContainer(t.State)
+		}
+
+		if quote != "" {
+			// Within a string; wait for the closing quote
+			if t.Type == Punctuation && t.Value == quote {
+				quote = ""
+			}
+			return out(t)
+		}
+
+		if t.Type == Whitespace && v.Lines && strings.Contains(t.Value, "\n") {
+			// End of document
+			if err := emitAll(out, held...); err != nil {
+				return err
+			}
+			held = nil
+			if expect != expectEOF && !(expect == expectValue &&
+				depth == 0) {
+				errs = append(errs, at.Error(
+					"unexpected end of line, expected %s",
+					expect.describe(open)))
+			}
+			depth, expect = 0, expectValue
+			return out(t)
+		}
+
+		if t.Type == Whitespace {
+			if held != nil {
+				held = append(held, t)
+				return nil
+			}
+			return out(t)
+		}
+
+		if t.Type == Punctuation && (t.Value == `"` || t.Value == "'") {
+			quote = t.Value
+		}
+
+		check(&t, at)
+
+		if t.Type == Comment && held != nil {
+			held = append(held, t)
+			return nil
+		}
+
+		if held != nil {
+			if t.Type == Punctuation && (t.Value == "}" || t.Value == "]") &&
+				!v.AllowTrailingCommas {
+				fail(&held[0], heldPos, "trailing comma before %q", t.Value)
+			}
+			err := emitAll(out, held...)
+			held = nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if t.Type == Punctuation && t.Value == "," {
+			held, heldPos = []Token{t}, at
+			return nil
+		}
+
+		return out(t)
+	}
+}
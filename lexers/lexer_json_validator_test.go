@@ -0,0 +1,87 @@
+package lexers_test
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONValidator(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		// Errors lists the expected error messages, in order
+		Errors []string
+		// Invalid lists the values of tokens expected to be marked Error
+		Invalid []string
+	}{
+		{`{}`, nil, nil},
+		{`[]`, nil, nil},
+		{"{\"a\": [1, 2, {\"b\": null}],\n \"c\": \"\"}", nil, nil},
+		{`"x"`, nil, nil},
+		{``, []string{
+			"line 1, column 1: unexpected end of input, expected a value",
+		}, nil},
+		{`[1 2]`, []string{
+			"line 1, column 4: expected ',' or ']' but found \"2\"",
+		}, []string{"2"}},
+		{`[1,]`, []string{
+			"line 1, column 3: trailing comma before \"]\"",
+		}, []string{","}},
+		{"{\"a\": 1,\n  }", []string{
+			"line 1, column 8: trailing comma before \"}\"",
+		}, []string{","}},
+		{`[1,,2]`, []string{
+			"line 1, column 4: expected a value but found ','",
+		}, []string{","}},
+		{`{"a": 1}}`, []string{
+			"line 1, column 9: unexpected \"}\"",
+		}, []string{"}"}},
+		{`{"a": }`, []string{
+			"line 1, column 7: expected a value but found \"}\"",
+		}, []string{"}"}},
+		{`[1} `, []string{
+			"line 1, column 3: unexpected \"} \"",
+			"line 1, column 5: unexpected end of input, expected ',' or ']'",
+		}, []string{"} "}},
+		{"{\"a\": 1\n", []string{
+			"line 2, column 1: unexpected end of input, expected ',' or '}'",
+		}, nil},
+		{`{"a": [1] `, []string{
+			"line 1, column 11: unexpected end of input, expected ',' or '}'",
+		}, nil},
+		{`{"a": [1]`, []string{
+			"line 1, column 10: unexpected end of input, " +
+				"expected ',' or a closing bracket",
+		}, nil},
+		{`1 2`, []string{
+			"line 1, column 3: expected end of input but found \"2\"",
+		}, []string{"2"}},
+	} {
+		var invalid []string
+		err := lexers.JSON.Validate(
+			bufio.NewReader(strings.NewReader(item.Subject)),
+			func(t Token) error {
+				if t.Type == Error {
+					invalid = append(invalid, t.Value)
+				}
+				return nil
+			})
+
+		if item.Errors == nil {
+			assert.Equal(t, io.EOF, err, item.Subject)
+		} else if errs, ok := err.(ValidationErrors); assert.True(t, ok,
+			item.Subject) {
+			messages := []string{}
+			for _, e := range errs {
+				messages = append(messages, e.Error())
+			}
+			assert.Equal(t, item.Errors, messages, item.Subject)
+		}
+		assert.Equal(t, item.Invalid, invalid, item.Subject)
+	}
+}
@@ -0,0 +1,38 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// jsonCommentStates match JavaScript-style comments between JSON tokens.
+var jsonCommentStates = StatesSpec{
+	"whitespace": {
+		{Regexp: `\s+`, Type: Whitespace},
+		{Regexp: `//.*`, Type: Comment},
+		{Regexp: `/\*`, Type: Comment, State: "multiLineComment"},
+	},
+	"multiLineComment": {
+		{Regexp: `\*/`, Type: Comment, State: "#pop"},
+		{Regexp: `[^*]+`, Type: Comment},
+		{Regexp: `\*`, Type: Comment},
+	},
+}
+
+// JSONC is JSON with comments, as used by many configuration files.
+var JSONC = Lexer{
+	Name:      "jsonc",
+	MimeTypes: []string{"application/jsonc"},
+	Filenames: []string{"*.jsonc"},
+	States:    jsonStates(jsonCommentStates),
+	Filters: []Filter{
+		RemoveEmptiesFilter,
+	},
+	Formatter: &JSONFormatter{Indent: "  "},
+	Minifier:  JSONMinifier,
+	Validator: &JSONValidator{
+		AllowComments:       true,
+		AllowTrailingCommas: true,
+	},
+}
+
+func init() {
+	Register(JSONC.Name, JSONC)
+}
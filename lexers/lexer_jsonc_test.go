@@ -0,0 +1,41 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerJSONC(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "// settings", Type: Comment},
+		{Value: "\n", Type: Whitespace},
+		{Value: "{", Type: Punctuation},
+		{Value: " ", Type: Whitespace},
+		{Value: "/*", Type: Comment},
+		{Value: " a\n", Type: Comment},
+		{Value: " ", Type: Comment},
+		{Value: "*/", Type: Comment},
+		{Value: " ", Type: Whitespace},
+		{Value: `"`, Type: Punctuation},
+		{Value: "a", Type: Attribute},
+		{Value: `"`, Type: Punctuation},
+		{Value: ":", Type: Assignment},
+		{Value: " ", Type: Whitespace},
+		{Value: "1", Type: Number},
+		{Value: ",", Type: Punctuation},
+		{Value: " ", Type: Whitespace},
+		{Value: "}", Type: Punctuation},
+	}, tokenize(t, lexers.JSONC, "// settings\n{ /* a\n */ \"a\": 1, }"))
+
+	formatted := render(t, lexers.JSONC.Format,
+		"// settings\n{\"a\": [1, /* one */ 2], // two\n\"b\": {/* c */}}")
+	assert.Equal(t, "// settings\n{\n  \"a\": [\n    1,\n    /* one */\n"+
+		"    2\n  ],\n  // two\n  \"b\": {\n    /* c */\n  }\n}", formatted)
+
+	assert.Equal(t, `{"a":[1,2],"b":{}}`,
+		render(t, lexers.JSONC.Minify, formatted))
+	assert.Equal(t, formatted, render(t, lexers.JSONC.Validate, formatted))
+}
@@ -0,0 +1,79 @@
+package lexers
+
+import (
+	"strconv"
+	"time"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// logfmtValue matches a quoted or bare logfmt value.
+const logfmtValue = `"(?:[^"\\\r\n]|\\.)*"|[^\s"]+`
+
+// Logfmt matches logfmt lines of `key=value` pairs, as written by many Go
+// logging libraries, e.g.
+//
+//	ts=2016-01-01T12:00:00Z level=warn msg="disk full" free=0.3
+//
+// The values of `level` and `time` keys are emitted as Level and Timestamp
+// respectively, and numbers, durations and booleans as Number and Literal.
+var Logfmt = Lexer{
+	Name:      "logfmt",
+	MimeTypes: []string{"text/x-logfmt"},
+	Filenames: []string{"*.logfmt"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `(level|lvl|severity)(=)`,
+				SubTypes: []TokenType{Attribute, Assignment},
+				State:    "level"},
+			{Regexp: `(time|ts|timestamp|t)(=)`,
+				SubTypes: []TokenType{Attribute, Assignment},
+				State:    "timestamp"},
+			{Regexp: `([^\s="]+)(=)`,
+				SubTypes: []TokenType{Attribute, Assignment},
+				State:    "value"},
+			// Keys without a value
+			{Regexp: `[^\s="]+`, Type: Attribute},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"|"|=`, Type: Text},
+		},
+		"level": {
+			{Regexp: logfmtValue, Type: Level, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+		"timestamp": {
+			{Regexp: logfmtValue, Type: Timestamp, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+		"value": {
+			{Regexp: logfmtValue, Type: String, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter, logfmtValueFilter},
+}
+
+// logfmtValueFilter emits bare values that are numbers or durations as
+// Number, and `true`, `false` and `null` as Literal.
+var logfmtValueFilter = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		return func(t Token) error {
+			if t.State == "value" && t.Type == String {
+				if _, err := strconv.ParseFloat(t.Value, 64); err == nil {
+					t.Type = Number
+				} else if _, err := time.ParseDuration(t.Value); err == nil {
+					t.Type = Number
+				} else {
+					switch t.Value {
+					case "true", "false", "null", "nil":
+						t.Type = Literal
+					}
+				}
+			}
+			return out(t)
+		}
+	})
+
+func init() {
+	Register(Logfmt.Name, Logfmt)
+}
@@ -0,0 +1,44 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerLogfmt(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "ts", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "3000-01-01T00:00:00Z", Type: Timestamp},
+		{Value: " ", Type: Whitespace},
+		{Value: "level", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "warn", Type: Level},
+		{Value: " ", Type: Whitespace},
+		{Value: "msg", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: `"disk full"`, Type: String},
+		{Value: " ", Type: Whitespace},
+		{Value: "free", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "0.3", Type: Number},
+		{Value: " ", Type: Whitespace},
+		{Value: "took", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "12ms", Type: Number},
+		{Value: " ", Type: Whitespace},
+		{Value: "ok", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "true", Type: Literal},
+		{Value: " ", Type: Whitespace},
+		{Value: "user", Type: Attribute},
+		{Value: "=", Type: Assignment},
+		{Value: "fry", Type: String},
+		{Value: " ", Type: Whitespace},
+		{Value: "debug", Type: Attribute},
+	}, tokenize(t, lexers.Logfmt, `ts=3000-01-01T00:00:00Z level=warn `+
+		`msg="disk full" free=0.3 took=12ms ok=true user=fry debug`))
+}
@@ -0,0 +1,141 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// Makefile matches GNU and POSIX makefiles. Variable references are emitted
+// as Attribute, and automatic variables such as `$@` as Keyword.
+var Makefile = Lexer{
+	Name:      "makefile",
+	Aliases:   []string{"make", "mk", "gnumake"},
+	MimeTypes: []string{"text/x-makefile"},
+	Filenames: []string{"Makefile", "makefile", "GNUmakefile", "*.mk",
+		"*.mak", "*.make"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\r?\n`, Type: Whitespace},
+			// Recipe lines start with a tab
+			{Regexp: `\t`, Type: Whitespace, State: "recipe recipePrefix"},
+			{Regexp: ` +`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			{Regexp: `(define)([ \t]+)([^\s:#=]+)([ \t]*)` +
+				`(::=|:::=|:=|\?=|\+=|!=|=)?`,
+				SubTypes: []TokenType{Keyword, Whitespace, Attribute,
+					Whitespace, Assignment},
+				State: "define"},
+			// Modifiers preceding an assignment, e.g. `export PATH := ...`
+			{Regexp: `(override|export|private)([ \t]+)`,
+				SubTypes: []TokenType{Keyword, Whitespace}},
+			{Regexp: `(?:-?include|sinclude|override|export|unexport|` +
+				`undefine|vpath|ifeq|ifneq|ifdef|ifndef|else|endif)\b`,
+				Type: Keyword, State: "expression"},
+			{Regexp: `([^\s:#=]+)([ \t]*)(::=|:::=|:=|\?=|\+=|!=|=)([ \t]*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "value"},
+			// Targets, e.g. `all:` or `%.o: %.c`
+			{Regexp: `([^\s:#=][^:#=\r\n]*?)([ \t]*)(::?|&:)`,
+				SubTypes: []TokenType{Tag, Whitespace, Punctuation},
+				State:    "prerequisites"},
+			{Regexp: ``, State: "expression"},
+		},
+		// prerequisites matches the remainder of a rule, which may be
+		// followed by a recipe on the same line following `;`.
+		"prerequisites": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `\\\r?\n`, Type: Punctuation},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			{Regexp: `;`, Type: Punctuation, State: "#pop recipe"},
+			{Regexp: `[|:]`, Type: Punctuation},
+			{Include: "references"},
+			{Regexp: `[^\s;|:#$\\]+|[$\\]`, Type: Text},
+		},
+		// recipe matches a line of a recipe, including any continuation
+		// lines.
+		"recipe": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `\\\r?\n\t?`, Type: Punctuation},
+			{Include: "references"},
+			{Regexp: `[^\r\n$\\]+|[$\\]`, Type: Text},
+		},
+		// recipePrefix matches any prefixes suppressing echoing or errors,
+		// e.g. `@` in `@echo`
+		"recipePrefix": {
+			{Regexp: `[@+-]+`, Type: Punctuation, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+		// value matches the value of a variable assignment
+		"value": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `\\\r?\n`, Type: Punctuation},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			{Include: "references"},
+			{Regexp: `[^\r\n$\\#]+|[$\\]`, Type: String},
+		},
+		// define matches the body of a multi-line variable
+		"define": {
+			{Regexp: `[ \t]*endef\b[^\r\n]*`, Type: Keyword, State: "#pop"},
+			{Regexp: `\r?\n`, Type: Whitespace},
+			{Include: "references"},
+			{Regexp: `[^\r\n$]+|\$`, Type: String},
+		},
+		// expression matches the arguments of directives such as `ifeq`
+		// and `include`, and any other line.
+		"expression": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `\\\r?\n`, Type: Punctuation},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			{Include: "references"},
+			{Regexp: `[(),]`, Type: Punctuation},
+			{Regexp: `"[^"\r\n]*"|'[^'\r\n]*'`, Type: String},
+			{Regexp: `[^\s$(),"'#\\]+|[$"'\\]`, Type: Text},
+		},
+		// references matches variable references and function calls, e.g.
+		// `$(CC)`, `${SRC:.c=.o}` or `$(patsubst %.c,%.o,$(SRC))`
+		"references": {
+			{Regexp: `\$\$`, Type: Text},
+			// Automatic variables, e.g. `$@` or `$(@D)`
+			{Regexp: `\$[@<^?*+|%]|\$[({][@<^?*+|%][DF][)}]`, Type: Keyword},
+			{Regexp: `(\$[({])((?:subst|patsubst|strip|findstring|filter|` +
+				`filter-out|sort|word|words|wordlist|firstword|lastword|dir|` +
+				`notdir|suffix|basename|addsuffix|addprefix|join|wildcard|` +
+				`realpath|abspath|if|or|and|intcmp|foreach|let|file|call|` +
+				`value|eval|origin|flavor|error|warning|info|shell|guile)` +
+				`\b)([ \t]*)`,
+				SubTypes: []TokenType{Punctuation, Keyword, Whitespace},
+				State:    "call"},
+			{Regexp: `\$[({]`, Type: Punctuation, State: "reference"},
+			{Regexp: `\$[A-Za-z0-9_]`, Type: Attribute},
+		},
+		"reference": {
+			{Regexp: `[)}]`, Type: Punctuation, State: "#pop"},
+			// Substitution references, e.g. `$(SRC:.c=.o)`
+			{Regexp: `[:=]`, Type: Punctuation},
+			{Include: "references"},
+			{Regexp: `[^\s:=(){}$]+`, Type: Attribute},
+			{Regexp: `[^)}$]`, Type: Text},
+		},
+		// call matches the arguments of a function call, which may contain
+		// balanced parentheses.
+		"call": {
+			{Regexp: `[)}]`, Type: Punctuation, State: "#pop"},
+			{Include: "arguments"},
+		},
+		"arguments": {
+			{Regexp: `[({]`, Type: Text, State: "group"},
+			{Regexp: `,`, Type: Punctuation},
+			{Include: "references"},
+			{Regexp: `[^$(){},]+|\$`, Type: Text},
+		},
+		"group": {
+			{Regexp: `[)}]`, Type: Text, State: "#pop"},
+			{Include: "arguments"},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(Makefile.Name, Makefile)
+}
@@ -0,0 +1,94 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerMakefile(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"OBJ ::= $(SRC:.c=.o) # objects", []Token{
+			{Value: "OBJ", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "::=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "$(", Type: Punctuation},
+			{Value: "SRC", Type: Attribute},
+			{Value: ":", Type: Punctuation},
+			{Value: ".c", Type: Attribute},
+			{Value: "=", Type: Punctuation},
+			{Value: ".o", Type: Attribute},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: String},
+			{Value: "# objects", Type: Comment},
+		}},
+		{"app: $(OBJ) | dist\n\t@$(CC) -o $@ $^", []Token{
+			{Value: "app", Type: Tag},
+			{Value: ":", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "$(", Type: Punctuation},
+			{Value: "OBJ", Type: Attribute},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "dist", Type: Text},
+			{Value: "\n", Type: Whitespace},
+			{Value: "\t", Type: Whitespace},
+			{Value: "@", Type: Punctuation},
+			{Value: "$(", Type: Punctuation},
+			{Value: "CC", Type: Attribute},
+			{Value: ")", Type: Punctuation},
+			{Value: " -o ", Type: Text},
+			{Value: "$@", Type: Keyword},
+			{Value: " ", Type: Text},
+			{Value: "$^", Type: Keyword},
+		}},
+		{"ifeq ($(OS),Windows)\nX += $(wildcard *.c)\nendif", []Token{
+			{Value: "ifeq", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "(", Type: Punctuation},
+			{Value: "$(", Type: Punctuation},
+			{Value: "OS", Type: Attribute},
+			{Value: ")", Type: Punctuation},
+			{Value: ",", Type: Punctuation},
+			{Value: "Windows", Type: Text},
+			{Value: ")", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "X", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "+=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "$(", Type: Punctuation},
+			{Value: "wildcard", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "*.c", Type: Text},
+			{Value: ")", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "endif", Type: Keyword},
+		}},
+		{"define greet =\necho $(1)\nendef", []Token{
+			{Value: "define", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "greet", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "echo ", Type: String},
+			{Value: "$(", Type: Punctuation},
+			{Value: "1", Type: Attribute},
+			{Value: ")", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "endef", Type: Keyword},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.Makefile,
+			item.Subject), item.Subject)
+	}
+}
@@ -0,0 +1,165 @@
+package lexers
+
+import (
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// Markdown tokenizes CommonMark documents, along with GitHub Flavored
+// Markdown tables, task lists, strikethrough and bare URLs. Fenced code
+// blocks are tokenized by the Tokenizer named by their info string, e.g.
+// "json" in ```json, if one is registered.
+//
+// Headings are emitted as Keyword, emphasis as Literal, link text as Tag,
+// and link destinations as Attribute.
+var Markdown = Lexer{
+	Name:      "markdown",
+	Aliases:   []string{"md", "gfm", "commonmark"},
+	MimeTypes: []string{"text/markdown", "text/x-markdown"},
+	Filenames: []string{"*.md", "*.markdown"},
+	States: StatesSpec{
+		// root matches block structure at the start of a line, before
+		// moving on to the inline content of the line.
+		"root": {
+			{Regexp: `\r?\n`, Type: Whitespace},
+			{Regexp: "([ \t]*)(```+)",
+				SubTypes: []TokenType{Whitespace, Punctuation},
+				Capture:  2, State: "backtickFence fenceInfo"},
+			{Regexp: `([ \t]*)(~~~+)`,
+				SubTypes: []TokenType{Whitespace, Punctuation},
+				Capture:  2, State: "tildeFence fenceInfo"},
+			{Regexp: `(#{1,6})([ \t]+)`,
+				SubTypes: []TokenType{Punctuation, Whitespace},
+				State:    "heading"},
+			// Thematic breaks and setext heading underlines
+			{Regexp: `((?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,}|` +
+				`=+[ \t]*)(\r?\n|$)`,
+				SubTypes: []TokenType{Punctuation, Whitespace}},
+			// Table delimiter rows, e.g. `| --- | :-: |`
+			{Regexp: `(\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)+\|?` +
+				`[ \t]*|\|[ \t]*:?-+:?[ \t]*\|[ \t]*)(\r?\n|$)`,
+				SubTypes: []TokenType{Punctuation, Whitespace}},
+			// Link reference definitions, e.g. `[fry]: https://example.com`
+			{Regexp: `(\[)([^\]\r\n]+)(\])(:)([ \t]*)(\S+)` +
+				`([ \t]+)?("[^"\r\n]*"|'[^'\r\n]*')?`,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation, Punctuation,
+					Whitespace, Attribute, Whitespace, String}},
+			{Regexp: `(>)([ \t]?)`,
+				SubTypes: []TokenType{Punctuation, Whitespace}},
+			{Regexp: `([-+*]|[0-9]{1,9}[.)])([ \t]+)`,
+				SubTypes: []TokenType{Punctuation, Whitespace},
+				State:    "listItem"},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: ``, State: "inline"},
+		},
+		"heading": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `[^\r\n]+`, Type: Keyword},
+		},
+		// listItem matches the checkbox of a task list item, e.g. `[x]`
+		"listItem": {
+			{Regexp: `(\[[ xX]\])([ \t]+|$)`,
+				SubTypes: []TokenType{Literal, Whitespace}, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+		// fenceInfo matches the info string following an opening fence, the
+		// first word of which names the language of the block.
+		"fenceInfo": {
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: "([^\\s`]+)([^\\r\\n]*)",
+				SubTypes: []TokenType{Attribute, Text}},
+			{Regexp: `\r?\n|$`, Type: Whitespace, State: "#pop"},
+		},
+		// backtickFence and tildeFence match the content of a fenced code
+		// block, which ends at a fence of the same character at least as
+		// long as the one captured on entering the state.
+		"backtickFence": {
+			{Regexp: "^([ \t]*)({captured}`*)([ \t]*)(\r?\n|$)",
+				SubTypes: []TokenType{Whitespace, Punctuation, Whitespace,
+					Whitespace},
+				State: "#pop"},
+			{Regexp: `[^\r\n]*\r?\n|[^\r\n]+`, Type: Text},
+		},
+		"tildeFence": {
+			{Regexp: `^([ \t]*)({captured}~*)([ \t]*)(\r?\n|$)`,
+				SubTypes: []TokenType{Whitespace, Punctuation, Whitespace,
+					Whitespace},
+				State: "#pop"},
+			{Regexp: `[^\r\n]*\r?\n|[^\r\n]+`, Type: Text},
+		},
+		// inline matches the content of a line, such as emphasis, links and
+		// code spans.
+		"inline": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `<!--`, Type: Comment, State: "comment"},
+			{Regexp: "\\\\[!-/:-@\\[-`{-~]", Type: Literal},
+			{Regexp: `&(?:[A-Za-z][A-Za-z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`,
+				Type: Literal},
+			{Regexp: "``[^\\r\\n]+?``|`[^`\\r\\n]+`", Type: String},
+			// Links and images, e.g. `[text](url "title")` or `![alt][ref]`
+			{Regexp: `(!?\[)([^\]\r\n]*)(\])(\()([^)\s]*)` +
+				`([ \t]+)?("[^"\r\n]*")?(\))`,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation,
+					Punctuation, Attribute, Whitespace, String, Punctuation}},
+			{Regexp: `(!?\[)([^\]\r\n]*)(\])(\[)([^\]\r\n]*)(\])`,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation,
+					Punctuation, Attribute, Punctuation}},
+			// Autolinks, e.g. `<https://example.com>`
+			{Regexp: `(<)([A-Za-z][\w+.-]*:[^\s<>]*|[^\s@<>]+@[^\s@<>]+)(>)`,
+				SubTypes: []TokenType{Punctuation, Attribute, Punctuation}},
+			{Regexp: `</?[A-Za-z][\w-]*(?:[^>"'\r\n]|"[^"]*"|'[^']*')*/?>`,
+				Type: Tag},
+			{Regexp: `(?:https?|ftp)://[^\s<>]*[^\s<>.,:;"')\]*_~]`,
+				Type: Attribute},
+			// Strong emphasis, emphasis and strikethrough
+			{Regexp: `(\*\*)([^\s*](?:[^*\r\n]*[^\s*])?)(\*\*)`,
+				SubTypes: []TokenType{Punctuation, Literal, Punctuation}},
+			{Regexp: `(__)([^\s_](?:[^_\r\n]*[^\s_])?)(__)`,
+				SubTypes: []TokenType{Punctuation, Literal, Punctuation}},
+			{Regexp: `(\*)([^\s*](?:[^*\r\n]*[^\s*])?)(\*)`,
+				SubTypes: []TokenType{Punctuation, Literal, Punctuation}},
+			{Regexp: `(_)([^\s_](?:[^_\r\n]*[^\s_])?)(_)`,
+				SubTypes: []TokenType{Punctuation, Literal, Punctuation}},
+			{Regexp: `(~~)([^\s~](?:[^~\r\n]*[^\s~])?)(~~)`,
+				SubTypes: []TokenType{Punctuation, Literal, Punctuation}},
+			// Table cell separators
+			{Regexp: `\|`, Type: Punctuation},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			// Words, which may contain underscores, e.g. `snake_case`
+			{Regexp: `[\pL\pN]+(?:_+[\pL\pN]+)*`, Type: Text},
+			{Regexp: "[^\\s\\pL\\pN*_`\\[!<&\\\\|~]+|[*_`\\[!<&\\\\~]",
+				Type: Text},
+		},
+		"comment": {
+			{Regexp: `-->`, Type: Comment, State: "#pop"},
+			{Regexp: `[^-]+|-`, Type: Comment},
+		},
+	},
+	Filters: []Filter{
+		RemoveEmptiesFilter,
+		DelegateFilter{
+			States: []string{"backtickFence", "tildeFence"},
+			Types:  []TokenType{Text},
+			Select: selectMarkdownTokenizer,
+		},
+	},
+}
+
+// selectMarkdownTokenizer selects the Tokenizer for a fenced code block by
+// the first word of its info string, e.g. "json" or "{.json}", leaving the
+// block as plain text if no such Tokenizer is registered.
+func selectMarkdownTokenizer(t Token, current Tokenizer) Tokenizer {
+	switch {
+	case t.Type == Punctuation && t.State == "root" &&
+		(strings.HasPrefix(t.Value, "```") || strings.HasPrefix(t.Value, "~~~")):
+		return nil
+	case t.Type == Attribute && t.State == "fenceInfo":
+		return GetTokenizer(strings.Trim(t.Value, "{}.,"))
+	}
+	return current
+}
+
+func init() {
+	Register(Markdown.Name, Markdown)
+}
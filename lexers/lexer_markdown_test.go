@@ -0,0 +1,105 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerMarkdown(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"## Fry\n> **Bender** _is_ `great`", []Token{
+			{Value: "##", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "Fry", Type: Keyword},
+			{Value: "\n", Type: Whitespace},
+			{Value: ">", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "**", Type: Punctuation},
+			{Value: "Bender", Type: Literal},
+			{Value: "**", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "_", Type: Punctuation},
+			{Value: "is", Type: Literal},
+			{Value: "_", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "`great`", Type: String},
+		}},
+		{"- [x] see [docs](https://x.io) or snake_case\n", []Token{
+			{Value: "-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "[x]", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: "see", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "[", Type: Punctuation},
+			{Value: "docs", Type: Tag},
+			{Value: "]", Type: Punctuation},
+			{Value: "(", Type: Punctuation},
+			{Value: "https://x.io", Type: Attribute},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "or", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "snake_case", Type: Text},
+			{Value: "\n", Type: Whitespace},
+		}},
+		{"| a |\n|---|\n", []Token{
+			{Value: "|", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "a", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "|---|", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+		}},
+		{"```json\n[1]\n```\n~~~unknown\n[1]\n~~~", []Token{
+			{Value: "```", Type: Punctuation},
+			{Value: "json", Type: Attribute},
+			{Value: "\n", Type: Whitespace},
+			{Value: "[", Type: Punctuation},
+			{Value: "1", Type: Number},
+			{Value: "]", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "```", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "~~~", Type: Punctuation},
+			{Value: "unknown", Type: Attribute},
+			{Value: "\n", Type: Whitespace},
+			{Value: "[1]\n", Type: Text},
+			{Value: "~~~", Type: Punctuation},
+		}},
+		{"```shell\necho $HOME\n```", []Token{
+			{Value: "```", Type: Punctuation},
+			{Value: "shell", Type: Attribute},
+			{Value: "\n", Type: Whitespace},
+			{Value: "echo", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "$HOME", Type: Attribute},
+			{Value: "\n", Type: Whitespace},
+			{Value: "```", Type: Punctuation},
+		}},
+		{"````unknown\n```json\n~~~~\n```\n`````\n*x*", []Token{
+			{Value: "````", Type: Punctuation},
+			{Value: "unknown", Type: Attribute},
+			{Value: "\n", Type: Whitespace},
+			{Value: "```json\n", Type: Text},
+			{Value: "~~~~\n", Type: Text},
+			{Value: "```\n", Type: Text},
+			{Value: "`````", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "*", Type: Punctuation},
+			{Value: "x", Type: Literal},
+			{Value: "*", Type: Punctuation},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.Markdown, item.Subject),
+			item.Subject)
+	}
+}
@@ -0,0 +1,52 @@
+package lexers
+
+import (
+	"math"
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// NDJSON matches newline-delimited JSON (also known as JSON Lines), where
+// each line contains a separate document. Each line is tokenized
+// independently, so that a malformed line does not affect those following.
+var NDJSON = Lexer{
+	Name: "ndjson",
+	MimeTypes: []string{"application/x-ndjson", "application/ndjson",
+		"application/jsonl", "application/x-jsonlines"},
+	Filenames: []string{"*.ndjson", "*.jsonl"},
+	States: jsonStates(StatesSpec{
+		"whitespace": {
+			{Regexp: `[ \t\r]+`, Type: Whitespace},
+			{Regexp: `\n`, Type: Whitespace, State: "#reset"},
+		},
+	}),
+	Filters: []Filter{
+		RemoveEmptiesFilter,
+	},
+	Formatter: &JSONFormatter{
+		Width:           math.MaxInt32,
+		Lines:           true,
+		TrailingNewline: true,
+	},
+	Minifier:  NDJSONMinifier,
+	Validator: &JSONValidator{Lines: true},
+}
+
+// NDJSONMinifier removes all whitespace between JSON tokens, except for the
+// newlines separating each document.
+var NDJSONMinifier = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		return func(t Token) error {
+			if t.Type == Whitespace && strings.Contains(t.Value, "\n") {
+				t.Value = "\n"
+			} else if t.Type == Whitespace || t.Type == Comment {
+				return nil
+			}
+			return out(t)
+		}
+	})
+
+func init() {
+	Register(NDJSON.Name, NDJSON)
+}
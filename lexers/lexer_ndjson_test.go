@@ -0,0 +1,45 @@
+package lexers_test
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerNDJSON(t *testing.T) {
+	// A malformed line shouldn't affect those that follow
+	assert.Equal(t, []Token{
+		{Value: "{", Type: Punctuation},
+		{Value: `"`, Type: Punctuation},
+		{Value: "a", Type: Attribute},
+		{Value: `"`, Type: Punctuation},
+		{Value: ":", Type: Assignment},
+		{Value: "\n", Type: Whitespace},
+		{Value: "[", Type: Punctuation},
+		{Value: "1", Type: Number},
+		{Value: "]", Type: Punctuation},
+		{Value: "\n", Type: Whitespace},
+		{Value: "{", Type: Punctuation},
+		{Value: `"a" 1}`, Type: Error},
+		{Value: "\n", Type: Whitespace},
+		{Value: "null", Type: Literal},
+	}, tokenize(t, lexers.NDJSON, "{\"a\":\n[1]\n{\"a\" 1}\nnull"))
+
+	assert.Equal(t, "{\"a\": [1, 2]}\n{\"b\": {}}\n",
+		render(t, lexers.NDJSON.Format, "{ \"a\" : [1,2] }\n\n{\"b\":{}}"))
+	assert.Equal(t, "{\"a\":[1,2]}\n{\"b\":{}}\n",
+		render(t, lexers.NDJSON.Minify, "{ \"a\" : [1,2] }\n{\"b\":{}}\n"))
+
+	err := lexers.NDJSON.Validate(
+		bufio.NewReader(strings.NewReader("{\"a\":\n[1]\n\n[2] 3\n")),
+		func(Token) error { return nil })
+	assert.Equal(t, ValidationErrors{
+		{Line: 1, Column: 6, Message: "unexpected end of line, " +
+			"expected a value"},
+		{Line: 4, Column: 5, Message: "expected end of input but found \"3\""},
+	}, err)
+}
@@ -0,0 +1,45 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// propertiesKey matches the key of a Java properties entry, which ends at
+// the first unescaped separator or whitespace.
+const propertiesKey = `((?:[^\s=:\\]|\\[^\r\n])+)`
+
+var Properties = Lexer{
+	Name:      "properties",
+	Aliases:   []string{"java-properties"},
+	MimeTypes: []string{"text/x-java-properties", "text/x-properties"},
+	Filenames: []string{"*.properties"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `[#!][^\r\n]*`, Type: Comment},
+			{Regexp: propertiesKey + `([ \t\f]*)([=:])([ \t\f]*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "value"},
+			{Regexp: propertiesKey + `([ \t\f]*)`,
+				SubTypes: []TokenType{Attribute, Whitespace},
+				State:    "value"},
+		},
+		"value": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			// Line continuations, after which leading whitespace is ignored
+			{Regexp: `(\\)(\r?\n)`, SubTypes: []TokenType{Punctuation,
+				Whitespace},
+				State: "continuation"},
+			{Regexp: `\\u[0-9a-fA-F]{4}|\\[^\r\n]`, Type: Literal},
+			{Regexp: `[^\\\r\n]+`, Type: String},
+		},
+		"continuation": {
+			{Regexp: `[ \t\f]+`, Type: Whitespace, State: "#pop"},
+			{Regexp: ``, State: "#pop"},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(Properties.Name, Properties)
+}
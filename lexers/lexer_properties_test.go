@@ -0,0 +1,36 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerProperties(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "! c", Type: Comment},
+		{Value: "\n", Type: Whitespace},
+		{Value: `first\ name`, Type: Attribute},
+		{Value: " ", Type: Whitespace},
+		{Value: "=", Type: Assignment},
+		{Value: " ", Type: Whitespace},
+		{Value: "Philip", Type: String},
+		{Value: `\u0020`, Type: Literal},
+		{Value: "J.", Type: String},
+		{Value: `\`, Type: Punctuation},
+		{Value: "\n", Type: Whitespace},
+		{Value: "    ", Type: Whitespace},
+		{Value: "Fry", Type: String},
+		{Value: "\n", Type: Whitespace},
+		{Value: "age", Type: Attribute},
+		{Value: " ", Type: Whitespace},
+		{Value: "1000", Type: String},
+		{Value: "\n", Type: Whitespace},
+		{Value: "ship", Type: Attribute},
+		{Value: ":", Type: Assignment},
+		{Value: "Planet Express", Type: String},
+	}, tokenize(t, lexers.Properties, "! c\nfirst\\ name = Philip\\u0020J.\\\n"+
+		"    Fry\nage 1000\nship:Planet Express"))
+}
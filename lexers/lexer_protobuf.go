@@ -0,0 +1,87 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// Protobuf matches Protocol Buffers definitions using the proto2 or proto3
+// syntax, or editions. Message, enum and service names, and field types
+// that aren't scalars, are emitted as Tag.
+var Protobuf = Lexer{
+	Name:      "protobuf",
+	Aliases:   []string{"proto", "proto2", "proto3"},
+	MimeTypes: []string{"text/x-protobuf", "text/x-proto"},
+	Filenames: []string{"*.proto"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `//[^\r\n]*`, Type: Comment},
+			{Regexp: `/\*`, Type: Comment, State: "comment"},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"|'(?:[^'\\\r\n]|\\.)*'`,
+				Type: String},
+			// Declarations, e.g. `message SearchRequest`
+			{Regexp: `(message|enum|service|extend|oneof)(\s+)([\pL_][\w.]*)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Tag}},
+			{Regexp: `(rpc)(\s+)([\pL_]\w*)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Attribute},
+				State:    "rpc"},
+			// Options, e.g. `option (my_option).a = true;`
+			{Regexp: `(option)(\s+)(\(?[\pL_][\w.]*\)?(?:\.[\pL_][\w.]*)*)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Attribute}},
+			{Regexp: `(syntax|edition)(\s*)(=)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Assignment}},
+			// Enum values, options and fields whose names are keywords,
+			// e.g. `UNIVERSAL = 0` or the `max` of `int32 max = 1`
+			{Regexp: `([\pL_]\w*)(\s*)(=)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment}},
+			{Regexp: `(?:syntax|edition|package|import|weak|public|option|` +
+				`extensions|reserved|to|max|repeated|optional|required|` +
+				`group|stream|returns)\b`,
+				Type: Keyword},
+			{Regexp: `(map)(\s*)(<)`,
+				SubTypes: []TokenType{Keyword, Whitespace, Punctuation},
+				State:    "map"},
+			{Include: "scalars"},
+			{Regexp: `(?:true|false|inf|nan)\b`, Type: Literal},
+			// Fields, e.g. `Result results = 1`
+			{Regexp: `(\.?[\pL_][\w.]*)(\s+)([\pL_]\w*)(\s*)(=)`,
+				SubTypes: []TokenType{Tag, Whitespace, Attribute, Whitespace,
+					Assignment}},
+			{Regexp: `-?(?:0[xX][0-9a-fA-F]+|[0-9]+(?:\.[0-9]*)?` +
+				`(?:[eE][-+]?[0-9]+)?|\.[0-9]+(?:[eE][-+]?[0-9]+)?)`,
+				Type: Number},
+			{Regexp: `\(?[\pL_][\w.]*\)?`, Type: Text},
+			{Regexp: `[{}\[\]();,<>:.]`, Type: Punctuation},
+			{Regexp: `=`, Type: Assignment},
+			{Regexp: `-`, Type: Operator},
+		},
+		"scalars": {
+			{Regexp: `(?:double|float|int32|int64|uint32|uint64|sint32|` +
+				`sint64|fixed32|fixed64|sfixed32|sfixed64|bool|string|bytes)\b`,
+				Type: Keyword},
+		},
+		// map matches the key and value types of a map field
+		"map": {
+			{Regexp: `>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `,`, Type: Punctuation},
+			{Include: "scalars"},
+			{Regexp: `\.?[\pL_][\w.]*`, Type: Tag},
+		},
+		// rpc matches the request and response types of a method
+		"rpc": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `[()]`, Type: Punctuation},
+			{Regexp: `(?:stream|returns)\b`, Type: Keyword},
+			{Regexp: `\.?[\pL_][\w.]*`, Type: Tag},
+			{Regexp: `[;{]`, Type: Punctuation, State: "#pop"},
+		},
+		"comment": {
+			{Regexp: `\*/`, Type: Comment, State: "#pop"},
+			{Regexp: `[^*]+|\*`, Type: Comment},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(Protobuf.Name, Protobuf)
+}
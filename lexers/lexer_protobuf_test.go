@@ -0,0 +1,141 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerProtobuf(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{`syntax = "proto3";`, []Token{
+			{Value: "syntax", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: `"proto3"`, Type: String},
+			{Value: ";", Type: Punctuation},
+		}},
+		{"message Crew {\n  repeated .v1.Person members = 1;\n" +
+			"  map<string, Ship> ships = 2 [deprecated = true];\n" +
+			"  reserved 3 to max;\n}",
+			[]Token{
+				{Value: "message", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: "Crew", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "{", Type: Punctuation},
+				{Value: "\n", Type: Whitespace},
+				{Value: "  ", Type: Whitespace},
+				{Value: "repeated", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: ".v1.Person", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "members", Type: Attribute},
+				{Value: " ", Type: Whitespace},
+				{Value: "=", Type: Assignment},
+				{Value: " ", Type: Whitespace},
+				{Value: "1", Type: Number},
+				{Value: ";", Type: Punctuation},
+				{Value: "\n", Type: Whitespace},
+				{Value: "  ", Type: Whitespace},
+				{Value: "map", Type: Keyword},
+				{Value: "<", Type: Punctuation},
+				{Value: "string", Type: Keyword},
+				{Value: ",", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "Ship", Type: Tag},
+				{Value: ">", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "ships", Type: Attribute},
+				{Value: " ", Type: Whitespace},
+				{Value: "=", Type: Assignment},
+				{Value: " ", Type: Whitespace},
+				{Value: "2", Type: Number},
+				{Value: " ", Type: Whitespace},
+				{Value: "[", Type: Punctuation},
+				{Value: "deprecated", Type: Attribute},
+				{Value: " ", Type: Whitespace},
+				{Value: "=", Type: Assignment},
+				{Value: " ", Type: Whitespace},
+				{Value: "true", Type: Literal},
+				{Value: "]", Type: Punctuation},
+				{Value: ";", Type: Punctuation},
+				{Value: "\n", Type: Whitespace},
+				{Value: "  ", Type: Whitespace},
+				{Value: "reserved", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: "3", Type: Number},
+				{Value: " ", Type: Whitespace},
+				{Value: "to", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: "max", Type: Keyword},
+				{Value: ";", Type: Punctuation},
+				{Value: "\n", Type: Whitespace},
+				{Value: "}", Type: Punctuation},
+			}},
+		{"rpc Deliver(stream Package) returns (Receipt);", []Token{
+			{Value: "rpc", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "Deliver", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: "stream", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "Package", Type: Tag},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "returns", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "(", Type: Punctuation},
+			{Value: "Receipt", Type: Tag},
+			{Value: ")", Type: Punctuation},
+			{Value: ";", Type: Punctuation},
+		}},
+		{"enum Rank { CAPTAIN = 0x1; } // c", []Token{
+			{Value: "enum", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "Rank", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "CAPTAIN", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "0x1", Type: Number},
+			{Value: ";", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "}", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "// c", Type: Comment},
+		}},
+		// Field names may be keywords
+		{"int32 max = 1; Ship to = 2;", []Token{
+			{Value: "int32", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "max", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "1", Type: Number},
+			{Value: ";", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "Ship", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "to", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "2", Type: Number},
+			{Value: ";", Type: Punctuation},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.Protobuf,
+			item.Subject), item.Subject)
+	}
+}
@@ -0,0 +1,248 @@
+package lexers
+
+import (
+	"regexp"
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// pythonDigits matches decimal digits with optional `_` separators
+const pythonDigits = `[0-9](?:_?[0-9])*`
+
+// pythonQuotes lists the quotes delimiting Python strings, along with the
+// suffix of the names of the states matching their contents. Triple quotes
+// come first so that they take precedence.
+var pythonQuotes = []struct{ quote, state string }{
+	{`'''`, "TripleSingleQuoted"},
+	{`"""`, "TripleDoubleQuoted"},
+	{`'`, "SingleQuoted"},
+	{`"`, "DoubleQuoted"},
+}
+
+// pythonPrefixes lists the string prefixes, grouped by whether they make
+// the string raw or formatted, along with the prefix of the names of the
+// states matching their contents. Bytes and Unicode strings are otherwise
+// tokenized the same as any other string.
+var pythonPrefixes = []struct {
+	regexp, state string
+	raw, format   bool
+}{
+	{`(?i:fr|rf)`, "rawF", true, true},
+	{`(?i:rb|br|r)`, "raw", true, false},
+	{`[fF]`, "f", false, true},
+	{`(?i:b|u)?`, "", false, false},
+}
+
+// pythonSoftKeywords lists the keywords that are only keywords at the start
+// of a statement.
+var pythonSoftKeywords = map[string]bool{
+	"match": true,
+	"case":  true,
+	"type":  true,
+}
+
+// Python tokenizes Python 3 source. Function names are emitted as Attribute
+// and class names and decorators as Tag.
+var Python = Lexer{
+	Name:    "python",
+	Aliases: []string{"py", "python3", "py3"},
+	MimeTypes: []string{"text/x-python", "application/x-python",
+		"text/x-python3"},
+	Filenames: []string{"*.py", "*.pyi", "*.pyw"},
+	States:    pythonStates(),
+	Filters:   []Filter{RemoveEmptiesFilter, pythonSoftKeywordFilter},
+}
+
+// pythonStates returns the states of the Python lexer, including a state
+// for the contents of each kind of string.
+func pythonStates() StatesSpec {
+	var strs []RuleSpec
+	states := StatesSpec{}
+	for _, p := range pythonPrefixes {
+		for _, q := range pythonQuotes {
+			state := p.state + q.state
+			if p.state == "" {
+				state = strings.ToLower(q.state[:1]) + q.state[1:]
+			}
+			strs = append(strs, RuleSpec{Regexp: p.regexp + q.quote,
+				Type: String, State: state})
+			states[state] = pythonString(q.quote, p.raw, p.format)
+		}
+	}
+
+	states["root"] = []RuleSpec{
+		// Decorators, e.g. `@functools.cache`
+		{Regexp: `(@)([\pL_][\pL\pN_.]*)`,
+			SubTypes: []TokenType{Punctuation, Tag}},
+		{Include: "expression"},
+	}
+	states["expression"] = append(append([]RuleSpec{
+		{Regexp: `(\\)(\r?\n)`, SubTypes: []TokenType{Punctuation,
+			Whitespace}},
+		{Regexp: `\s+`, Type: Whitespace},
+		{Regexp: `#[^\r\n]*`, Type: Comment},
+	}, strs...), []RuleSpec{
+		{Regexp: `(?:0[xX](?:_?[0-9a-fA-F])+|0[oO](?:_?[0-7])+|` +
+			`0[bB](?:_?[01])+|(?:` + pythonDigits + `(?:\.(?:` + pythonDigits +
+			`)?)?|\.` + pythonDigits + `)(?:[eE][-+]?` + pythonDigits +
+			`)?[jJ]?)`,
+			Type: Number},
+		{Regexp: `(def)(\s+)([\pL_][\pL\pN_]*)`,
+			SubTypes: []TokenType{Keyword, Whitespace, Attribute}},
+		{Regexp: `(class)(\s+)([\pL_][\pL\pN_]*)`,
+			SubTypes: []TokenType{Keyword, Whitespace, Tag}},
+		// Attribute references, e.g. `.append`
+		{Regexp: `(\.)([\pL_][\pL\pN_]*)`,
+			SubTypes: []TokenType{Punctuation, Attribute}},
+		{Regexp: `(?:False|None|True|NotImplemented|__debug__)\b`,
+			Type: Literal},
+		{Regexp: `(?:and|as|assert|async|await|break|class|continue|def|` +
+			`del|elif|else|except|finally|for|from|global|if|import|in|is|` +
+			`lambda|nonlocal|not|or|pass|raise|return|try|while|with|yield)\b`,
+			Type: Keyword},
+		{Regexp: `(?:abs|all|any|ascii|bin|bool|breakpoint|bytearray|bytes|` +
+			`callable|chr|classmethod|compile|complex|delattr|dict|dir|` +
+			`divmod|enumerate|eval|exec|filter|float|format|frozenset|` +
+			`getattr|globals|hasattr|hash|help|hex|id|input|int|isinstance|` +
+			`issubclass|iter|len|list|locals|map|max|memoryview|min|next|` +
+			`object|oct|open|ord|pow|print|property|range|repr|reversed|` +
+			`round|set|setattr|slice|sorted|staticmethod|str|sum|super|` +
+			`tuple|type|vars|zip|__import__)\b`,
+			Type: Attribute},
+		{Regexp: `[\pL_][\pL\pN_]*`, Type: Text},
+		{Regexp: `\*\*=|//=|>>=|<<=|[-+*/%&|^@]=|:=`, Type: Assignment},
+		{Regexp: `==|!=|<=|>=|->|\*\*|//|<<|>>|[-+*/%&|^~<>@]`,
+			Type: Operator},
+		{Regexp: `=`, Type: Assignment},
+		{Regexp: `\.\.\.`, Type: Literal},
+		{Regexp: `[()\[\]{},;.:]`, Type: Punctuation},
+	}...)
+
+	// replacementField matches the expression within a replacement field of
+	// an f-string, e.g. `{name!r:>{width}}`, up to its conversion or format
+	// specifier.
+	states["replacementField"] = []RuleSpec{
+		{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+		{Regexp: `![rsa]\b`, Type: Attribute},
+		{Regexp: `:`, Type: Punctuation, State: "#pop formatSpec"},
+		{Regexp: `[(\[{]`, Type: Punctuation, State: "brackets"},
+		{Include: "expression"},
+	}
+	// brackets matches an expression nested within a replacement field, in
+	// which `:` doesn't start the format specifier.
+	states["brackets"] = []RuleSpec{
+		{Regexp: `[)\]}]`, Type: Punctuation, State: "#pop"},
+		{Regexp: `[(\[{]`, Type: Punctuation, State: "brackets"},
+		{Include: "expression"},
+	}
+	// formatSpec matches a format specifier, which may itself contain
+	// replacement fields, e.g. `>{width}`
+	states["formatSpec"] = []RuleSpec{
+		{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+		{Regexp: `\{`, Type: Punctuation, State: "replacementField"},
+		{Regexp: `[^{}]+`, Type: Literal},
+	}
+	return states
+}
+
+// pythonString returns the rules matching the contents of a string
+// delimited by the given quote.
+func pythonString(quote string, raw, format bool) []RuleSpec {
+	rules := []RuleSpec{
+		{Regexp: quote, Type: String, State: "#pop"},
+	}
+	if raw {
+		rules = append(rules, RuleSpec{Regexp: `\\[\s\S]`, Type: String})
+	} else {
+		rules = append(rules,
+			RuleSpec{Regexp: `\\(?:[\\'"abfnrtv\n]|\r\n|[0-7]{1,3}|` +
+				`x[0-9a-fA-F]{2}|u[0-9a-fA-F]{4}|U[0-9a-fA-F]{8}|N\{[^}]*\})`,
+				Type: Literal},
+			RuleSpec{Regexp: `\\`, Type: String})
+	}
+	if format {
+		rules = append(rules,
+			RuleSpec{Regexp: `\{\{|\}\}`, Type: Literal},
+			RuleSpec{Regexp: `\{`, Type: Punctuation,
+				State: "replacementField"})
+	} else {
+		rules = append(rules, RuleSpec{Regexp: `[{}]`, Type: String})
+	}
+
+	q := regexp.QuoteMeta(quote[:1])
+	if len(quote) == 1 {
+		// Single-quoted strings end at the end of the line
+		rules = append(rules,
+			RuleSpec{Regexp: `[^\\{}\r\n` + q + `]+`, Type: String},
+			RuleSpec{Regexp: `\r?\n`, Type: Error, State: "#pop"})
+	} else {
+		rules = append(rules,
+			RuleSpec{Regexp: `[^\\{}` + q + `]+|` + q, Type: String})
+	}
+	return rules
+}
+
+// pythonSoftKeywordFilter emits the soft keywords `match`, `case` and
+// `type` as Keyword when they start a statement and are followed by
+// something other than an operator or delimiter, e.g. `match command:`
+// but not `match = re.match(...)`.
+var pythonSoftKeywordFilter = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		// pending holds a candidate soft keyword and the whitespace
+		// following it
+		var pending []Token
+		statement := true
+
+		return func(t Token) error {
+			if len(pending) > 0 {
+				if t.Type == Whitespace &&
+					!strings.ContainsAny(t.Value, "\r\n") {
+					pending = append(pending, t)
+					return nil
+				}
+				if len(pending) > 1 && pythonSoftKeywordFollows(t) {
+					pending[0].Type = Keyword
+				}
+				for _, p := range pending {
+					if err := out(p); err != nil {
+						return err
+					}
+				}
+				pending = nil
+			}
+
+			switch {
+			case (t.Type == Text || t.Type == Attribute) && statement &&
+				pythonSoftKeywords[t.Value]:
+				pending = append(pending, t)
+				statement = false
+				return nil
+			case t.Type == Whitespace || t.Type == Comment:
+				if strings.ContainsAny(t.Value, "\r\n") {
+					statement = true
+				}
+			default:
+				statement = t.Type == Punctuation && t.Value == ";"
+			}
+			return out(t)
+		}
+	})
+
+// pythonSoftKeywordFollows returns true if the given token may follow a
+// soft keyword.
+func pythonSoftKeywordFollows(t Token) bool {
+	switch t.Type {
+	case Assignment:
+		return false
+	case Operator:
+		return t.Value == "-" || t.Value == "*" || t.Value == "~"
+	case Punctuation:
+		return strings.ContainsAny(t.Value, "([{")
+	}
+	return t != EndToken
+}
+
+func init() {
+	Register(Python.Name, Python)
+}
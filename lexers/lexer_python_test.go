@@ -0,0 +1,99 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerPython(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"@cache\ndef fry(n=1_000):  # c", []Token{
+			{Value: "@", Type: Punctuation},
+			{Value: "cache", Type: Tag},
+			{Value: "\n", Type: Whitespace},
+			{Value: "def", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "fry", Type: Attribute},
+			{Value: "(", Type: Punctuation},
+			{Value: "n", Type: Text},
+			{Value: "=", Type: Assignment},
+			{Value: "1_000", Type: Number},
+			{Value: ")", Type: Punctuation},
+			{Value: ":", Type: Punctuation},
+			{Value: "  ", Type: Whitespace},
+			{Value: "# c", Type: Comment},
+		}},
+		{`f"{name!r:>{w}} {d['k']}{{"`, []Token{
+			{Value: `f"`, Type: String},
+			{Value: "{", Type: Punctuation},
+			{Value: "name", Type: Text},
+			{Value: "!r", Type: Attribute},
+			{Value: ":", Type: Punctuation},
+			{Value: ">", Type: Literal},
+			{Value: "{", Type: Punctuation},
+			{Value: "w", Type: Text},
+			{Value: "}", Type: Punctuation},
+			{Value: "}", Type: Punctuation},
+			{Value: " ", Type: String},
+			{Value: "{", Type: Punctuation},
+			{Value: "d", Type: Text},
+			{Value: "[", Type: Punctuation},
+			{Value: "'", Type: String},
+			{Value: "k", Type: String},
+			{Value: "'", Type: String},
+			{Value: "]", Type: Punctuation},
+			{Value: "}", Type: Punctuation},
+			{Value: "{{", Type: Literal},
+			{Value: `"`, Type: String},
+		}},
+		{`rb'\d' + u"\n"`, []Token{
+			{Value: "rb'", Type: String},
+			{Value: `\d`, Type: String},
+			{Value: "'", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "+", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: `u"`, Type: String},
+			{Value: `\n`, Type: Literal},
+			{Value: `"`, Type: String},
+		}},
+		{"'''Fry's\n'''", []Token{
+			{Value: "'''", Type: String},
+			{Value: "Fry", Type: String},
+			{Value: "'", Type: String},
+			{Value: "s\n", Type: String},
+			{Value: "'''", Type: String},
+		}},
+		{"match cmd:\n    case -1:\n        pass\nmatch = 0x1F", []Token{
+			{Value: "match", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "cmd", Type: Text},
+			{Value: ":", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "    ", Type: Whitespace},
+			{Value: "case", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "-", Type: Operator},
+			{Value: "1", Type: Number},
+			{Value: ":", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "        ", Type: Whitespace},
+			{Value: "pass", Type: Keyword},
+			{Value: "\n", Type: Whitespace},
+			{Value: "match", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "0x1F", Type: Number},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.Python, item.Subject),
+			item.Subject)
+	}
+}
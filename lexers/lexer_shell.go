@@ -0,0 +1,261 @@
+package lexers
+
+import (
+	"regexp"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// shellWord matches a character that may appear within an unquoted word.
+const shellWord = `[^\s$"'\x60\\;&|<>(){}]`
+
+// shellHeredocDelimiter matches the delimiter of a here-document, e.g.
+// `EOF` or `end-of-file`.
+const shellHeredocDelimiter = `[\w.-]+`
+
+// shellKeywords lists the reserved words of the POSIX shell and bash.
+var shellKeywords = shellWordSet("if", "then", "elif", "else", "fi", "case",
+	"esac", "for", "select", "while", "until", "do", "done", "in", "function",
+	"time", "coproc", "[[", "]]", "!")
+
+// shellBuiltins lists the builtin commands of the POSIX shell and bash.
+var shellBuiltins = shellWordSet("alias", "bg", "bind", "break", "builtin",
+	"caller", "cd", "command", "compgen", "complete", "continue", "declare",
+	"dirs", "disown", "echo", "enable", "eval", "exec", "exit", "export",
+	"false", "fc", "fg", "getopts", "hash", "help", "history", "jobs", "kill",
+	"let", "local", "logout", "mapfile", "popd", "printf", "pushd", "pwd",
+	"read", "readarray", "readonly", "return", "set", "shift", "shopt",
+	"source", "suspend", "test", "times", "trap", "true", "type", "typeset",
+	"ulimit", "umask", "unalias", "unset", "wait")
+
+// shellWordSet returns a set containing the given words.
+func shellWordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+var Shell = Lexer{
+	Name:    "shell",
+	Aliases: []string{"sh", "bash", "zsh", "ksh"},
+	MimeTypes: []string{"application/x-sh", "application/x-shellscript",
+		"text/x-sh", "text/x-shellscript"},
+	Filenames: []string{"*.sh", "*.bash", ".bashrc", ".bash_profile",
+		".profile"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `(\\)(\r?\n)`, SubTypes: []TokenType{Punctuation,
+				Whitespace}},
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			// Here-documents, e.g. `cat <<EOF` or `cat <<-'EOF'`, whose body
+			// ends at the line consisting of the unquoted delimiter
+			{Regexp: `(<<-)([ \t]*)(\\|'|")?(` + shellHeredocDelimiter +
+				`)('|")?`,
+				SubTypes: []TokenType{Operator, Whitespace, String, String,
+					String},
+				Capture: 4, State: "indentedHeredoc heredocLine"},
+			{Regexp: `(<<)([ \t]*)(\\|'|")?(` + shellHeredocDelimiter +
+				`)('|")?`,
+				SubTypes: []TokenType{Operator, Whitespace, String, String,
+					String},
+				Capture: 4, State: "heredoc heredocLine"},
+			{Include: "expansions"},
+			{Include: "quoting"},
+			{Regexp: `\(\(`, Type: Punctuation, State: "arithmetic"},
+			{Regexp: `[<>]\(`, Type: Punctuation, State: "subshell"},
+			{Regexp: `\(`, Type: Punctuation, State: "subshell"},
+			{Regexp: `[){}]`, Type: Punctuation},
+			// Redirections, e.g. `2>&1` or `<<<`
+			{Regexp: `[0-9]*(?:<<<|<>|>>|>&|<&|>\||&>>?|[<>])`,
+				Type: Operator},
+			{Regexp: `&&|\|\||\|&?|&`, Type: Operator},
+			{Regexp: `;;&?|;&|;`, Type: Punctuation},
+			// Function definitions, e.g. `name() {`
+			{Regexp: `([A-Za-z_][\w-]*)([ \t]*)(\(\))`,
+				SubTypes: []TokenType{Attribute, Whitespace, Punctuation}},
+			// Variable assignments, e.g. `name=value` or `list[0]+=value`
+			{Regexp: `([A-Za-z_]\w*)(\[[^\]\r\n]*\])?(\+?=)`,
+				SubTypes: []TokenType{Attribute, Text, Assignment}},
+			{Regexp: shellWord + `+`, Type: Text},
+		},
+		// quoting matches quoted strings and escaped characters
+		"quoting": {
+			{Regexp: `\$'(?:[^'\\]|\\[\s\S])*'`, Type: String},
+			{Regexp: `'`, Type: String, State: "singleQuoted"},
+			{Regexp: `"`, Type: String, State: "doubleQuoted"},
+			{Regexp: `\\[\s\S]`, Type: String},
+		},
+		"singleQuoted": {
+			{Regexp: `'`, Type: String, State: "#pop"},
+			{Regexp: `[^']+`, Type: String},
+		},
+		"doubleQuoted": {
+			{Regexp: `"`, Type: String, State: "#pop"},
+			{Regexp: `\\[\s\S]`, Type: String},
+			{Include: "expansions"},
+			{Regexp: "[^\"\\\\$`]+|\\$", Type: String},
+		},
+		// expansions matches parameter expansion, command substitution and
+		// arithmetic expansion, e.g. `$HOME`, `${name:-x}` or `$(date)`
+		"expansions": {
+			{Regexp: `\$\(\(`, Type: Punctuation, State: "arithmetic"},
+			{Regexp: `\$\(`, Type: Punctuation, State: "subshell"},
+			{Regexp: "`", Type: Punctuation, State: "backtick"},
+			{Regexp: `(\$\{)([#!]?)([A-Za-z_]\w*|[0-9]+|[@*#?$!-])`,
+				SubTypes: []TokenType{Punctuation, Operator, Attribute},
+				State:    "parameter"},
+			{Regexp: `\$(?:[A-Za-z_]\w*|[0-9]|[@*#?$!-])`, Type: Attribute},
+		},
+		"subshell": {
+			{Regexp: `\)`, Type: Punctuation, State: "#pop"},
+			{Include: "root"},
+		},
+		"backtick": {
+			{Regexp: "`", Type: Punctuation, State: "#pop"},
+			{Include: "root"},
+		},
+		// parameter matches the remainder of a `${...}` expansion
+		"parameter": {
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Regexp: `(\[)([^\]\r\n]*)(\])`,
+				SubTypes: []TokenType{Punctuation, Text, Punctuation}},
+			{Regexp: `:?[-=?+]|##?|%%?|/[/#%]?|\^\^?|,,?|@|:`,
+				Type: Operator, State: "#pop parameterWord"},
+		},
+		// parameterWord matches the word following an expansion's operator,
+		// e.g. `default` in `${name:-default}`
+		"parameterWord": {
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Include: "expansions"},
+			{Include: "quoting"},
+			{Regexp: "[^}$\"'`\\\\]+", Type: Text},
+		},
+		"arithmetic": {
+			{Regexp: `\)\)`, Type: Punctuation, State: "#pop"},
+			{Include: "arithmeticExpression"},
+		},
+		"arithmeticGroup": {
+			{Regexp: `\)`, Type: Punctuation, State: "#pop"},
+			{Include: "arithmeticExpression"},
+		},
+		"arithmeticExpression": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Include: "expansions"},
+			{Regexp: `0[xX][0-9a-fA-F]+|[0-9]+(?:#[0-9A-Za-z@_]+)?`,
+				Type: Number},
+			{Regexp: `[A-Za-z_]\w*`, Type: Attribute},
+			{Regexp: `\(`, Type: Punctuation, State: "arithmeticGroup"},
+			{Regexp: `[\[\],]`, Type: Punctuation},
+			{Regexp: `[-+*/%<>=!&|^~?:]+`, Type: Operator},
+		},
+		// heredocLine matches the remainder of the line on which a
+		// here-document begins, after which its body follows.
+		"heredocLine": {
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Include: "root"},
+		},
+		"heredoc":         heredocBody(false, String, String),
+		"indentedHeredoc": heredocBody(true, String, String),
+	},
+	Filters: []Filter{RemoveEmptiesFilter, shellWordFilter},
+}
+
+// heredocBody returns the rules matching the body of a here-document, which
+// ends at the line consisting of the delimiter captured on entering the
+// state. If tabs is set, as by `<<-`, the delimiter may be indented by tabs.
+// The lines of the body are emitted as body, and the delimiter as delimiter.
+func heredocBody(tabs bool, body, delimiter TokenType) []RuleSpec {
+	indent := ``
+	if tabs {
+		indent = `\t*`
+	}
+	return []RuleSpec{
+		{Regexp: `^(` + indent + `)({captured})(\r?\n|$)`,
+			SubTypes: []TokenType{Whitespace, delimiter, Whitespace},
+			State:    "#pop"},
+		{Regexp: `[^\r\n]+`, Type: body},
+		{Regexp: `\r?\n`, Type: Whitespace},
+	}
+}
+
+var shellNumber = regexp.MustCompile(`^[0-9]+$`)
+
+// shellWordFilter resolves unquoted words that are keywords, builtins or
+// numbers, e.g. `if`, `echo` or `1` in `if true; then exit 1; fi`.
+var shellWordFilter = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		return func(t Token) error {
+			if t.Type == Text && t.State != "parameterWord" {
+				switch {
+				case shellNumber.MatchString(t.Value):
+					t.Type = Number
+				case shellKeywords[t.Value]:
+					t.Type = Keyword
+				case shellBuiltins[t.Value]:
+					t.Type = Attribute
+				}
+			}
+			return out(t)
+		}
+	})
+
+// consoleUser matches the user, host and working directory that may precede
+// a prompt, e.g. `user@host:~` or `[user@host ~]`, optionally preceded by
+// a virtualenv name.
+const consoleUser = `(?:\([\w.-]+\) )?(?:\[[^\]\r\n]*\]|[\w.-]+@[\w.-]+` +
+	`(?::[^\s$#%>]*)?)`
+
+// Console tokenizes transcripts of interactive shell sessions, in which
+// commands follow a prompt such as `$ ` or `user@host:~$ `. Commands are
+// tokenized by the Shell lexer, and their output is left as Text.
+//
+// The first prompt determines the prompt character of the session, so that
+// output lines beginning with `# ` or `> `, such as Markdown, are only
+// mistaken for prompts if the session's prompts take that form.
+var Console = Lexer{
+	Name:      "console",
+	Aliases:   []string{"shell-session", "sh-session"},
+	MimeTypes: []string{"text/x-shell-session", "application/x-shell-session"},
+	Filenames: []string{"*.sh-session", "*.shell-session"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: consoleUser + `?([$#%>]) `, Type: Punctuation,
+				Capture: 1, State: "session command"},
+			{Regexp: `[^\r\n]+`, Type: Text},
+			{Regexp: `\r?\n`, Type: Whitespace},
+		},
+		// session matches the transcript following the first prompt, whose
+		// prompt character is captured
+		"session": {
+			{Regexp: consoleUser + `[$#%>] `, Type: Punctuation,
+				State: "command"},
+			{Regexp: `(?:\([\w.-]+\) )?{captured} `, Type: Punctuation,
+				State: "command"},
+			{Regexp: `[^\r\n]+`, Type: Text},
+			{Regexp: `\r?\n`, Type: Whitespace},
+		},
+		// command matches a command up to the end of the line, including any
+		// lines continued with a backslash
+		"command": {
+			{Regexp: `\\\r?\n`, Type: Text},
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `[^\r\n\\]+|\\`, Type: Text},
+		},
+	},
+	Filters: []Filter{
+		DelegateFilter{
+			States: []string{"command"},
+			Types:  []TokenType{Text},
+			Select: func(Token, Tokenizer) Tokenizer { return Shell },
+		},
+	},
+}
+
+func init() {
+	Register(Shell.Name, Shell)
+	Register(Console.Name, Console)
+}
@@ -0,0 +1,191 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerShell(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{`x="a $b ${c:-d}" # e`, []Token{
+			{Value: "x", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: `"`, Type: String},
+			{Value: "a ", Type: String},
+			{Value: "$b", Type: Attribute},
+			{Value: " ", Type: String},
+			{Value: "${", Type: Punctuation},
+			{Value: "c", Type: Attribute},
+			{Value: ":-", Type: Operator},
+			{Value: "d", Type: Text},
+			{Value: "}", Type: Punctuation},
+			{Value: `"`, Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "# e", Type: Comment},
+		}},
+		{"if [[ -n $(ls 'a b') ]]; then echo $((1 + x)) >&2; fi", []Token{
+			{Value: "if", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "[[", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "-n", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "$(", Type: Punctuation},
+			{Value: "ls", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "'", Type: String},
+			{Value: "a b", Type: String},
+			{Value: "'", Type: String},
+			{Value: ")", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "]]", Type: Keyword},
+			{Value: ";", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "then", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "echo", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "$((", Type: Punctuation},
+			{Value: "1", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "+", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "x", Type: Attribute},
+			{Value: "))", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: ">&", Type: Operator},
+			{Value: "2", Type: Number},
+			{Value: ";", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "fi", Type: Keyword},
+		}},
+		{"cat <<EOF | wc -l\ndone $x\nEOF\ndone", []Token{
+			{Value: "cat", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "<<", Type: Operator},
+			{Value: "EOF", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "wc", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "-l", Type: Text},
+			{Value: "\n", Type: Whitespace},
+			{Value: "done $x", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "EOF", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "done", Type: Keyword},
+		}},
+		{"cat <<eof\nhello\neof\necho hi", []Token{
+			{Value: "cat", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "<<", Type: Operator},
+			{Value: "eof", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "hello", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "eof", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "echo", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "hi", Type: Text},
+		}},
+		{"cat <<'EOF'\nDONE\n EOF\nEOF\nfi", []Token{
+			{Value: "cat", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "<<", Type: Operator},
+			{Value: "'", Type: String},
+			{Value: "EOF", Type: String},
+			{Value: "'", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "DONE", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: " EOF", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "EOF", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "fi", Type: Keyword},
+		}},
+		{"cat <<-END\n\tbody\n\tEND\n", []Token{
+			{Value: "cat", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "<<-", Type: Operator},
+			{Value: "END", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "\tbody", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "\t", Type: Whitespace},
+			{Value: "END", Type: String},
+			{Value: "\n", Type: Whitespace},
+		}},
+		{"f() { return `id`; }", []Token{
+			{Value: "f", Type: Attribute},
+			{Value: "()", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "return", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "`", Type: Punctuation},
+			{Value: "id", Type: Text},
+			{Value: "`", Type: Punctuation},
+			{Value: ";", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "}", Type: Punctuation},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.Shell, item.Subject),
+			item.Subject)
+	}
+}
+
+func TestLexerConsole(t *testing.T) {
+	assert.Equal(t, []Token{
+		{Value: "$ ", Type: Punctuation},
+		{Value: "echo", Type: Attribute},
+		{Value: " ", Type: Whitespace},
+		{Value: "$HOME", Type: Attribute},
+		{Value: "\n", Type: Whitespace},
+		{Value: "/home/fry", Type: Text},
+		{Value: "\n", Type: Whitespace},
+		{Value: "fry@express:~$ ", Type: Punctuation},
+		{Value: "exit", Type: Attribute},
+		{Value: " ", Type: Whitespace},
+		{Value: "1", Type: Number},
+	}, tokenize(t, lexers.Console,
+		"$ echo $HOME\n/home/fry\nfry@express:~$ exit 1"))
+
+	// Output resembling a prompt of another form is left as Text
+	assert.Equal(t, []Token{
+		{Value: "$ ", Type: Punctuation},
+		{Value: "cat", Type: Text},
+		{Value: " ", Type: Whitespace},
+		{Value: "README.md", Type: Text},
+		{Value: "\n", Type: Whitespace},
+		{Value: "# Title", Type: Text},
+		{Value: "\n", Type: Whitespace},
+		{Value: "> quote", Type: Text},
+		{Value: "\n", Type: Whitespace},
+		{Value: "$ ", Type: Punctuation},
+		{Value: "exit", Type: Attribute},
+	}, tokenize(t, lexers.Console,
+		"$ cat README.md\n# Title\n> quote\n$ exit"))
+
+	// ...but is a prompt if the session uses that form
+	assert.Equal(t, []Token{
+		{Value: "# ", Type: Punctuation},
+		{Value: "id", Type: Text},
+		{Value: "\n", Type: Whitespace},
+		{Value: "uid=0(root)", Type: Text},
+		{Value: "\n", Type: Whitespace},
+		{Value: "# ", Type: Punctuation},
+		{Value: "exit", Type: Attribute},
+	}, tokenize(t, lexers.Console, "# id\nuid=0(root)\n# exit"))
+}
@@ -0,0 +1,204 @@
+package lexers
+
+import (
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// sqlKeywords lists the keywords of standard SQL.
+var sqlKeywords = []string{
+	"ADD", "ALL", "ALTER", "AND", "ANY", "AS", "ASC", "BEGIN", "BETWEEN",
+	"BY", "CASCADE", "CASE", "CAST", "CHECK", "COLLATE", "COLUMN", "COMMIT",
+	"CONSTRAINT", "CREATE", "CROSS", "CURRENT_DATE", "CURRENT_TIME",
+	"CURRENT_TIMESTAMP", "DATABASE", "DEFAULT", "DELETE", "DESC",
+	"DISTINCT", "DROP", "ELSE", "END", "ESCAPE", "EXCEPT", "EXISTS",
+	"EXPLAIN", "FETCH", "FIRST", "FOREIGN", "FROM", "FULL", "GRANT",
+	"GROUP", "HAVING", "IF", "IN", "INDEX", "INNER", "INSERT", "INTERSECT",
+	"INTO", "IS", "JOIN", "KEY", "LAST", "LEFT", "LIKE", "LIMIT", "NATURAL",
+	"NEXT", "NOT", "NULLS", "OFFSET", "ON", "ONLY", "OR", "ORDER", "OUTER",
+	"OVER", "PARTITION", "PRIMARY", "REFERENCES", "REVOKE", "RIGHT",
+	"ROLLBACK", "ROW", "ROWS", "SAVEPOINT", "SCHEMA", "SELECT", "SET",
+	"SOME", "TABLE", "THEN", "TO", "TRANSACTION", "TRIGGER", "TRUNCATE",
+	"UNION", "UNIQUE", "UPDATE", "USING", "VALUES", "VIEW", "WHEN",
+	"WHERE", "WINDOW", "WITH",
+	// Data types
+	"BIGINT", "BINARY", "BLOB", "BOOLEAN", "CHAR", "CHARACTER", "CLOB",
+	"DATE", "DECIMAL", "DOUBLE", "FLOAT", "INT", "INTEGER", "INTERVAL",
+	"NUMERIC", "PRECISION", "REAL", "SMALLINT", "TEXT", "TIME", "TIMESTAMP",
+	"VARBINARY", "VARCHAR", "VARYING", "ZONE",
+}
+
+// sqlDialect describes the syntax of a particular SQL implementation.
+type sqlDialect struct {
+	// keywords lists the keywords of the dialect besides sqlKeywords.
+	keywords []string
+	// backticks and brackets allow identifiers to be quoted with
+	// backticks, e.g. `name`, or brackets, e.g. [name].
+	backticks, brackets bool
+	// doubleQuotedStrings treats double-quoted text as a string rather than
+	// an identifier.
+	doubleQuotedStrings bool
+	// backslashEscapes allows characters within strings to be escaped with
+	// a backslash.
+	backslashEscapes bool
+	// dollarQuotes enables PostgreSQL dollar-quoted strings, e.g.
+	// $$text$$, and `E'...'` escape strings.
+	dollarQuotes bool
+	// hashComments allows comments to begin with `#`.
+	hashComments bool
+}
+
+var SQL = Lexer{
+	Name:      "sql",
+	Aliases:   []string{"ansi", "ansi-sql"},
+	MimeTypes: []string{"application/sql", "text/x-sql"},
+	Filenames: []string{"*.sql"},
+	States:    sqlStates(sqlDialect{}),
+	Filters:   []Filter{RemoveEmptiesFilter},
+}
+
+var PostgreSQL = Lexer{
+	Name:      "postgresql",
+	Aliases:   []string{"postgres", "pgsql", "psql"},
+	MimeTypes: []string{"text/x-pgsql", "text/x-postgresql"},
+	Filenames: []string{"*.pgsql"},
+	States: sqlStates(sqlDialect{
+		keywords: []string{"ANALYZE", "BIGSERIAL", "BYTEA", "CONFLICT", "DO",
+			"EXTENSION", "FUNCTION", "ILIKE", "JSON", "JSONB", "LANGUAGE",
+			"LATERAL", "MATERIALIZED", "NOTHING", "OWNER", "REPLACE",
+			"RETURNING", "RETURNS", "SEQUENCE", "SERIAL", "SIMILAR", "UUID",
+			"VACUUM"},
+		dollarQuotes: true,
+	}),
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+var MySQL = Lexer{
+	Name:      "mysql",
+	Aliases:   []string{"mariadb"},
+	MimeTypes: []string{"text/x-mysql", "text/x-mariadb"},
+	States: sqlStates(sqlDialect{
+		keywords: []string{"AUTO_INCREMENT", "CHARSET", "DATETIME",
+			"DELIMITER", "DUPLICATE", "ENGINE", "ENUM", "IGNORE", "LONGTEXT",
+			"MEDIUMINT", "MEDIUMTEXT", "REGEXP", "RLIKE", "SHOW", "STRAIGHT_JOIN",
+			"TINYINT", "TINYTEXT", "UNSIGNED", "USE", "ZEROFILL"},
+		backticks:           true,
+		doubleQuotedStrings: true,
+		backslashEscapes:    true,
+		hashComments:        true,
+	}),
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+var SQLite = Lexer{
+	Name:      "sqlite",
+	Aliases:   []string{"sqlite3"},
+	MimeTypes: []string{"text/x-sqlite"},
+	States: sqlStates(sqlDialect{
+		keywords: []string{"ABORT", "ATTACH", "AUTOINCREMENT", "CONFLICT",
+			"DETACH", "FAIL", "GLOB", "IGNORE", "INDEXED", "MATCH", "PLAN",
+			"PRAGMA", "QUERY", "RAISE", "REGEXP", "REINDEX", "REPLACE", "ROWID",
+			"STRICT", "VACUUM", "VIRTUAL", "WITHOUT"},
+		backticks: true,
+		brackets:  true,
+	}),
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+// sqlStates returns the states of a lexer for the given SQL dialect.
+func sqlStates(d sqlDialect) StatesSpec {
+	keywords := append(append([]string{}, sqlKeywords...), d.keywords...)
+
+	root := []RuleSpec{
+		{Regexp: `\s+`, Type: Whitespace},
+		{Regexp: `--[^\r\n]*`, Type: Comment},
+		{Regexp: `/\*`, Type: Comment, State: "comment"},
+	}
+	if d.hashComments {
+		root = append(root, RuleSpec{Regexp: `#[^\r\n]*`, Type: Comment})
+	}
+	if d.dollarQuotes {
+		root = append(root,
+			RuleSpec{Regexp: `\$\$`, Type: String, State: "dollarQuoted"},
+			RuleSpec{Regexp: `\$([\pL_][\pL\pN_]*)\$`, Type: String,
+				Capture: 1, State: "taggedDollarQuoted"},
+			RuleSpec{Regexp: `[eE]'`, Type: String, State: "escapedString"})
+	}
+	if d.backslashEscapes {
+		root = append(root,
+			RuleSpec{Regexp: `(?:[nNxXbB]|_\w+)?'`, Type: String,
+				State: "escapedString"})
+	} else {
+		root = append(root,
+			RuleSpec{Regexp: `[nNxXbB]?'`, Type: String, State: "string"})
+	}
+	if d.doubleQuotedStrings {
+		root = append(root, RuleSpec{Regexp: `"(?:[^"\\]|""|\\[\s\S])*"`,
+			Type: String})
+	} else {
+		root = append(root, RuleSpec{Regexp: `"(?:[^"]|"")*"`, Type: Text})
+	}
+	if d.backticks {
+		root = append(root, RuleSpec{Regexp: "`(?:[^`]|``)*`", Type: Text})
+	}
+	if d.brackets {
+		root = append(root, RuleSpec{Regexp: `\[[^\]\r\n]*\]`, Type: Text})
+	}
+	root = append(root, []RuleSpec{
+		{Regexp: `0[xX][0-9a-fA-F]+|(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)` +
+			`(?:[eE][-+]?[0-9]+)?`,
+			Type: Number},
+		{Regexp: `(?i:TRUE|FALSE|NULL|UNKNOWN)\b`, Type: Literal},
+		{Regexp: `(?i:` + strings.Join(keywords, "|") + `)\b`,
+			Type: Keyword},
+		{Regexp: `[\pL_][\pL\pN_$]*`, Type: Text},
+		// Placeholders, e.g. `?`, `$1`, `:name` or `@name`
+		{Regexp: `::`, Type: Operator},
+		{Regexp: `\?[0-9]*|\$[0-9]+|[:@][\pL_][\pL\pN_]*|@@[\w.]+`,
+			Type: Attribute},
+		{Regexp: `\|\||->>?|#>>?|[<>!]=|<>|<=>|@>|<@|&&|<<|>>|` +
+			`[-+*/%=<>!~&|^@#]`,
+			Type: Operator},
+		{Regexp: `[(),;.\[\]:]`, Type: Punctuation},
+	}...)
+
+	states := StatesSpec{
+		"root": root,
+		"comment": {
+			{Regexp: `\*/`, Type: Comment, State: "#pop"},
+			{Regexp: `[^*]+|\*`, Type: Comment},
+		},
+		// string matches the contents of a string, in which a quote is
+		// escaped by doubling it, e.g. 'Fry''s'
+		"string": {
+			{Regexp: `[^']+|''`, Type: String},
+			{Regexp: `'`, Type: String, State: "#pop"},
+		},
+		// escapedString additionally allows escapes such as `\n` and `\'`
+		"escapedString": {
+			{Regexp: `[^'\\]+|''|\\[\s\S]`, Type: String},
+			{Regexp: `'`, Type: String, State: "#pop"},
+		},
+	}
+	if d.dollarQuotes {
+		states["dollarQuoted"] = []RuleSpec{
+			{Regexp: `\$\$`, Type: String, State: "#pop"},
+			{Regexp: `[^$]+|\$`, Type: String},
+		}
+		// taggedDollarQuoted ends at the tag it began with, e.g.
+		// $body$...$body$, so it may contain other tags
+		states["taggedDollarQuoted"] = []RuleSpec{
+			{Regexp: `\${captured}\$`, Type: String, State: "#pop"},
+			{Regexp: `[^$]+|\$`, Type: String},
+		}
+	}
+	return states
+}
+
+func init() {
+	Register(SQL.Name, SQL)
+	Register(PostgreSQL.Name, PostgreSQL)
+	Register(MySQL.Name, MySQL)
+	Register(SQLite.Name, SQLite)
+}
@@ -0,0 +1,124 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerSQL(t *testing.T) {
+	for _, item := range []struct {
+		Lexer   Lexer
+		Subject string
+		Tokens  []Token
+	}{
+		{lexers.SQL, `select "Name" from t where a = 'Fry''s' -- c`, []Token{
+			{Value: "select", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: `"Name"`, Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "from", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "t", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "where", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "a", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "'", Type: String},
+			{Value: "Fry", Type: String},
+			{Value: "''", Type: String},
+			{Value: "s", Type: String},
+			{Value: "'", Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "-- c", Type: Comment},
+		}},
+		{lexers.SQL, "LIMIT ? OFFSET :offset /* x */", []Token{
+			{Value: "LIMIT", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "?", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "OFFSET", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: ":offset", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "/*", Type: Comment},
+			{Value: " x ", Type: Comment},
+			{Value: "*/", Type: Comment},
+		}},
+		{lexers.PostgreSQL, "SELECT $1::int, $$it's$$, E'\\n'", []Token{
+			{Value: "SELECT", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "$1", Type: Attribute},
+			{Value: "::", Type: Operator},
+			{Value: "int", Type: Keyword},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "$$", Type: String},
+			{Value: "it's", Type: String},
+			{Value: "$$", Type: String},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "E'", Type: String},
+			{Value: `\n`, Type: String},
+			{Value: "'", Type: String},
+		}},
+		{lexers.PostgreSQL, "SELECT $a$ x $b$ y $a$;", []Token{
+			{Value: "SELECT", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "$a$", Type: String},
+			{Value: " x ", Type: String},
+			{Value: "$", Type: String},
+			{Value: "b", Type: String},
+			{Value: "$", Type: String},
+			{Value: " y ", Type: String},
+			{Value: "$a$", Type: String},
+			{Value: ";", Type: Punctuation},
+		}},
+		{lexers.MySQL, "SELECT `id`, \"a\" # c", []Token{
+			{Value: "SELECT", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "`id`", Type: Text},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: `"a"`, Type: String},
+			{Value: " ", Type: Whitespace},
+			{Value: "# c", Type: Comment},
+		}},
+		{lexers.SQLite, "PRAGMA [my table].x = 1.5e3", []Token{
+			{Value: "PRAGMA", Type: Keyword},
+			{Value: " ", Type: Whitespace},
+			{Value: "[my table]", Type: Text},
+			{Value: ".", Type: Punctuation},
+			{Value: "x", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Operator},
+			{Value: " ", Type: Whitespace},
+			{Value: "1.5e3", Type: Number},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, item.Lexer, item.Subject),
+			item.Subject)
+	}
+}
+
+func TestSQLDialects(t *testing.T) {
+	for name, expected := range map[string]string{
+		"sql":        "sql",
+		"ANSI":       "sql",
+		"PostgreSQL": "postgresql",
+		"postgres":   "postgresql",
+		"MySQL":      "mysql",
+		"mariadb":    "mysql",
+		"sqlite3":    "sqlite",
+	} {
+		tokenizer := GetTokenizer(name)
+		if assert.NotNil(t, tokenizer, name) {
+			assert.Equal(t, expected, tokenizer.(Lexer).Name, name)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// logLevel matches an upper-case log level within a message, e.g. `ERROR`
+// or `[WARN]`.
+const logLevel = `\[?\b(?:EMERG|ALERT|CRIT|CRITICAL|FATAL|PANIC|ERR|ERROR|` +
+	`SEVERE|WARN|WARNING|NOTICE|INFO|DEBUG|TRACE)\b\]?`
+
+// Syslog matches syslog messages in the BSD (RFC 3164) and IETF (RFC 5424)
+// formats, with or without a leading priority. The priority and any level
+// within the message are emitted as a Level.
+var Syslog = Lexer{
+	Name:      "syslog",
+	MimeTypes: []string{"text/x-syslog"},
+	Filenames: []string{"syslog", "messages", "auth.log", "kern.log",
+		"daemon.log", "*.syslog"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\r?\n`, Type: Whitespace},
+			// Priority, e.g. `<34>`
+			{Regexp: `<[0-9]{1,3}>`, Type: Level},
+			// RFC 5424 header, e.g.
+			// `1 2003-10-11T22:14:15.003Z host app 1234 ID47`
+			{Regexp: `([0-9]{1,2})( )([0-9]{4}-[0-9]{2}-[0-9]{2}T[^\s]+|-)` +
+				`( )([^\s]+)( )([^\s]+)( )([^\s]+)( )([^\s]+)( ?)`,
+				SubTypes: []TokenType{Number, Whitespace, Timestamp,
+					Whitespace, Tag, Whitespace, Attribute, Whitespace,
+					Number, Whitespace, Keyword, Whitespace},
+				State: "structuredData"},
+			// RFC 3164 header, e.g. `Oct 11 22:14:15 host`, or with the
+			// high-precision timestamps written by rsyslog
+			{Regexp: `([A-Z][a-z]{2} [ 0-9][0-9] [0-9]{2}:[0-9]{2}:[0-9]{2}|` +
+				`[0-9]{4}-[0-9]{2}-[0-9]{2}T[^\s]+)( +)([^\s]+)( +)`,
+				SubTypes: []TokenType{Timestamp, Whitespace, Tag, Whitespace},
+				State:    "tag"},
+			{Regexp: `[^\r\n]+`, Type: Text},
+		},
+		// tag matches the program name and process ID preceding the message,
+		// e.g. `sshd[1234]:`
+		"tag": {
+			{Regexp: `([^\s:\[]+)(?:(\[)([0-9]+)(\]))?(:)`,
+				SubTypes: []TokenType{Attribute, Punctuation, Number,
+					Punctuation, Punctuation},
+				State: "#pop message"},
+			{Regexp: ``, State: "#pop message"},
+		},
+		// structuredData matches the structured data of an RFC 5424 message,
+		// e.g. `[exampleSDID@32473 iut="3"]`, or `-` if there is none.
+		"structuredData": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `[ \t]+`, Type: Whitespace, State: "#pop message"},
+			{Regexp: `-`, Type: Punctuation},
+			{Regexp: `\[`, Type: Punctuation, State: "sdElement"},
+			{Regexp: ``, State: "#pop message"},
+		},
+		"sdElement": {
+			{Regexp: `\]`, Type: Punctuation, State: "#pop"},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `([^\s="\]]+)(=)("(?:[^"\\\r\n]|\\.)*")`,
+				SubTypes: []TokenType{Attribute, Assignment, String}},
+			{Regexp: `[^\s="\]]+`, Type: Tag},
+		},
+		"message": {
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: logLevel, Type: Level},
+			{Regexp: `[^A-Z\[\r\n]+|(?:[A-Z]+|\[)[^A-Z\[\r\n]*`, Type: Text},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(Syslog.Name, Syslog)
+}
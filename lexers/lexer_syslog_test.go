@@ -0,0 +1,60 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerSyslog(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"<34>Oct  1 22:14:15 express su[230]: ERROR su failed", []Token{
+			{Value: "<34>", Type: Level},
+			{Value: "Oct  1 22:14:15", Type: Timestamp},
+			{Value: " ", Type: Whitespace},
+			{Value: "express", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "su", Type: Attribute},
+			{Value: "[", Type: Punctuation},
+			{Value: "230", Type: Number},
+			{Value: "]", Type: Punctuation},
+			{Value: ":", Type: Punctuation},
+			{Value: " ", Type: Text},
+			{Value: "ERROR", Type: Level},
+			{Value: " su failed", Type: Text},
+		}},
+		{`<165>1 3000-01-01T00:00:00Z express app - ID47 [fry@1 x="1"] hi`,
+			[]Token{
+				{Value: "<165>", Type: Level},
+				{Value: "1", Type: Number},
+				{Value: " ", Type: Whitespace},
+				{Value: "3000-01-01T00:00:00Z", Type: Timestamp},
+				{Value: " ", Type: Whitespace},
+				{Value: "express", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "app", Type: Attribute},
+				{Value: " ", Type: Whitespace},
+				{Value: "-", Type: Number},
+				{Value: " ", Type: Whitespace},
+				{Value: "ID47", Type: Keyword},
+				{Value: " ", Type: Whitespace},
+				{Value: "[", Type: Punctuation},
+				{Value: "fry@1", Type: Tag},
+				{Value: " ", Type: Whitespace},
+				{Value: "x", Type: Attribute},
+				{Value: "=", Type: Assignment},
+				{Value: `"1"`, Type: String},
+				{Value: "]", Type: Punctuation},
+				{Value: " ", Type: Whitespace},
+				{Value: "hi", Type: Text},
+			}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.Syslog, item.Subject),
+			item.Subject)
+	}
+}
@@ -0,0 +1,85 @@
+package lexers
+
+import . "github.com/johnsto/go-highlight"
+
+// tomlKeys returns rules matching bare, quoted and dotted keys, emitted as
+// the given type.
+func tomlKeys(t TokenType) []RuleSpec {
+	return []RuleSpec{
+		{Regexp: `[A-Za-z0-9_-]+`, Type: t},
+		{Regexp: `"(?:[^"\\\r\n]|\\.)*"|'[^'\r\n]*'`, Type: t},
+		{Regexp: `[ \t]*\.[ \t]*`, Type: Punctuation},
+	}
+}
+
+var TOML = Lexer{
+	Name:      "toml",
+	MimeTypes: []string{"application/toml", "text/x-toml"},
+	Filenames: []string{"*.toml", "Cargo.lock", "Pipfile", "poetry.lock"},
+	States: StatesSpec{
+		"root": append([]RuleSpec{
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			// Tables and arrays of tables, e.g. `[server]` or `[[fruit]]`
+			{Regexp: `\[\[?`, Type: Punctuation, State: "table"},
+			{Regexp: `([ \t]*)(=)`, SubTypes: []TokenType{Whitespace,
+				Assignment}, State: "value"},
+		}, tomlKeys(Attribute)...),
+		"table": append([]RuleSpec{
+			{Regexp: `\]\]?`, Type: Punctuation, State: "#pop"},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+		}, tomlKeys(Tag)...),
+		// value matches a single value, following a key or within an array
+		"value": {
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `"""`, Type: String, State: "#pop multilineString"},
+			{Regexp: `'''`, Type: String, State: "#pop multilineLiteral"},
+			{Regexp: `"(?:[^"\\\r\n]|\\.)*"|'[^'\r\n]*'`, Type: String,
+				State: "#pop"},
+			// Offset and local date-times, dates and times
+			{Regexp: `[0-9]{4}-[0-9]{2}-[0-9]{2}(?:[Tt ][0-9]{2}:[0-9]{2}:` +
+				`[0-9]{2}(?:\.[0-9]+)?(?:[Zz]|[-+][0-9]{2}:[0-9]{2})?)?|` +
+				`[0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]+)?`,
+				Type: Number, State: "#pop"},
+			{Regexp: `0x[0-9a-fA-F](?:_?[0-9a-fA-F])*|0o[0-7](?:_?[0-7])*|` +
+				`0b[01](?:_?[01])*|[-+]?(?:inf|nan)|` +
+				`[-+]?[0-9](?:_?[0-9])*(?:\.[0-9](?:_?[0-9])*)?` +
+				`(?:[eE][-+]?[0-9](?:_?[0-9])*)?`,
+				Type: Number, State: "#pop"},
+			{Regexp: `(?:true|false)\b`, Type: Literal, State: "#pop"},
+			{Regexp: `\[`, Type: Punctuation, State: "#pop array"},
+			{Regexp: `\{`, Type: Punctuation, State: "#pop inlineTable"},
+			// Missing or invalid values, e.g. `key =` or `key = bare`
+			{Regexp: `\r?\n`, Type: Whitespace, State: "#pop"},
+			{Regexp: `[^\s,\]}#]+`, Type: Error, State: "#pop"},
+		},
+		// array matches the values of an array, which may span several
+		// lines and contain comments
+		"array": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+			{Regexp: `\]`, Type: Punctuation, State: "#pop"},
+			{Regexp: `,`, Type: Punctuation},
+			{Regexp: ``, State: "value"},
+		},
+		"inlineTable": append([]RuleSpec{
+			{Regexp: `\}`, Type: Punctuation, State: "#pop"},
+			{Regexp: `,`, Type: Punctuation},
+			{Regexp: `[ \t]+`, Type: Whitespace},
+			{Regexp: `=`, Type: Assignment, State: "value"},
+		}, tomlKeys(Attribute)...),
+		"multilineString": {
+			{Regexp: `"""`, Type: String, State: "#pop"},
+			{Regexp: `[^"\\]+|\\[\s\S]|"`, Type: String},
+		},
+		"multilineLiteral": {
+			{Regexp: `'''`, Type: String, State: "#pop"},
+			{Regexp: `[^']+|'`, Type: String},
+		},
+	},
+	Filters: []Filter{RemoveEmptiesFilter},
+}
+
+func init() {
+	Register(TOML.Name, TOML)
+}
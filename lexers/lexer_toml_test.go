@@ -0,0 +1,88 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerTOML(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"[[crew.members]] # c\nname = 'Fry'", []Token{
+			{Value: "[[", Type: Punctuation},
+			{Value: "crew", Type: Tag},
+			{Value: ".", Type: Punctuation},
+			{Value: "members", Type: Tag},
+			{Value: "]]", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "# c", Type: Comment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "name", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "'Fry'", Type: String},
+		}},
+		{"key = bare\nother = 1\n", []Token{
+			{Value: "key", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "bare", Type: Error},
+			{Value: "\n", Type: Whitespace},
+			{Value: "other", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "1", Type: Number},
+			{Value: "\n", Type: Whitespace},
+		}},
+		{`born = 2974-08-14T09:00:00Z`, []Token{
+			{Value: "born", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "2974-08-14T09:00:00Z", Type: Number},
+		}},
+		{"a = [1_000, 0xff,\n  {b = true}]", []Token{
+			{Value: "a", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "[", Type: Punctuation},
+			{Value: "1_000", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "0xff", Type: Number},
+			{Value: ",", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "  ", Type: Whitespace},
+			{Value: "{", Type: Punctuation},
+			{Value: "b", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "true", Type: Literal},
+			{Value: "}", Type: Punctuation},
+			{Value: "]", Type: Punctuation},
+		}},
+		{"s = \"\"\"\nx\"\"\"", []Token{
+			{Value: "s", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: "=", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: `"""`, Type: String},
+			{Value: "\n", Type: String},
+			{Value: "x", Type: String},
+			{Value: `"""`, Type: String},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.TOML, item.Subject),
+			item.Subject)
+	}
+}
@@ -0,0 +1,336 @@
+package lexers
+
+import (
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// xmlName matches an XML name, and xmlQName a name with an optional
+// namespace prefix, which is captured as three groups (prefix, colon, name).
+const (
+	xmlName  = `[\pL_][\pL\pN_.-]*`
+	xmlQName = `(?:(` + xmlName + `)(:))?(` + xmlName + `)`
+)
+
+var XML = Lexer{
+	Name: "xml",
+	MimeTypes: []string{"application/xml", "text/xml",
+		"application/soap+xml", "application/atom+xml",
+		"application/rss+xml", "application/xslt+xml",
+		"application/mathml+xml", "image/svg+xml"},
+	Filenames: []string{"*.xml", "*.xsd", "*.xsl", "*.xslt", "*.svg",
+		"*.rss", "*.atom", "*.wsdl", "*.plist"},
+	States: StatesSpec{
+		"root": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `[^<&\s]+(?:\s+[^<&\s]+)*`, Type: Text},
+			{Include: "entity"},
+			{Regexp: `<!--`, Type: Comment, State: "comment"},
+			{Regexp: `<!\[CDATA\[`, Type: Punctuation, State: "cdata"},
+			{Regexp: `(<!)(DOCTYPE)`, SubTypes: []TokenType{Punctuation, Tag},
+				State: "doctype"},
+			{Regexp: `(<\?)(` + xmlName + `)`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "processingInstruction"},
+			{Regexp: `(</)` + xmlQName,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation, Tag},
+				State:    "closeTag"},
+			{Regexp: `(<)` + xmlQName,
+				SubTypes: []TokenType{Punctuation, Tag, Punctuation, Tag},
+				State:    "tag"},
+		},
+		// entity matches entity and character references, e.g. `&amp;`
+		"entity": {
+			{Regexp: `&(?:` + xmlName + `|#[0-9]+|#x[0-9a-fA-F]+);`,
+				Type: Literal},
+			{Regexp: `&`, Type: Error},
+		},
+		"comment": {
+			{Regexp: `-->`, Type: Comment, State: "#pop"},
+			{Regexp: `[^-]+|-`, Type: Comment},
+		},
+		"cdata": {
+			{Regexp: `\]\]>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `[^\]]+|\]`, Type: Text},
+		},
+		// processingInstruction matches the body of a processing
+		// instruction, including the `<?xml ...?>` prolog, whose contents
+		// are commonly written as attributes.
+		"processingInstruction": {
+			{Regexp: `\?>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `(` + xmlName + `)(\s*)(=)(\s*)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace},
+				State: "attributeValue"},
+			{Regexp: `[^?\s]+|\?`, Type: Text},
+		},
+		"doctype": {
+			{Regexp: `>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `(SYSTEM|PUBLIC)\b`, Type: Literal},
+			{Regexp: `"[^"]*"|'[^']*'`, Type: String},
+			{Regexp: `\[`, Type: Punctuation, State: "internalSubset"},
+			{Regexp: xmlName, Type: Tag},
+		},
+		// internalSubset matches the markup declarations within a DOCTYPE
+		"internalSubset": {
+			{Regexp: `\]`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `<!--`, Type: Comment, State: "comment"},
+			{Regexp: `(<\?)(` + xmlName + `)`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "processingInstruction"},
+			{Regexp: `(<!)(ELEMENT|ATTLIST|ENTITY|NOTATION)\b`,
+				SubTypes: []TokenType{Punctuation, Tag},
+				State:    "markupDeclaration"},
+			{Regexp: `%` + xmlName + `;`, Type: Literal},
+		},
+		"markupDeclaration": {
+			{Regexp: `>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `"[^"]*"|'[^']*'`, Type: String},
+			{Regexp: `%(?:` + xmlName + `;)?`, Type: Literal},
+			{Regexp: `#(?:PCDATA|REQUIRED|IMPLIED|FIXED)\b`, Type: Literal},
+			{Regexp: `(SYSTEM|PUBLIC|NDATA|EMPTY|ANY)\b`, Type: Literal},
+			{Regexp: `[()|,*+?]`, Type: Punctuation},
+			{Regexp: `[\pL\pN_.:-]+`, Type: Attribute},
+		},
+		"tag": {
+			{Regexp: `/?>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: xmlQName + `(\s*)(=)(\s*)`,
+				SubTypes: []TokenType{Attribute, Punctuation, Attribute,
+					Whitespace, Assignment, Whitespace},
+				State: "attributeValue"},
+		},
+		"closeTag": {
+			{Regexp: `>`, Type: Punctuation, State: "#pop"},
+			{Regexp: `\s+`, Type: Whitespace},
+		},
+		"attributeValue": {
+			{Regexp: `"`, Type: Punctuation, State: "#pop doubleQuoted"},
+			{Regexp: `'`, Type: Punctuation, State: "#pop singleQuoted"},
+		},
+		"doubleQuoted": {
+			{Regexp: `"`, Type: Punctuation, State: "#pop"},
+			{Include: "entity"},
+			{Regexp: `[^"&]+`, Type: String},
+		},
+		"singleQuoted": {
+			{Regexp: `'`, Type: Punctuation, State: "#pop"},
+			{Include: "entity"},
+			{Regexp: `[^'&]+`, Type: String},
+		},
+	},
+	Filters:   []Filter{RemoveEmptiesFilter},
+	Formatter: &XMLFormatter{Indent: "  "},
+}
+
+// XMLFormatter consumes a series of XML tokens and emits additional tokens
+// to re-indent the document, placing each element, comment and processing
+// instruction on its own line.
+//
+// Elements containing only text are written on a single line, e.g.
+// `<name>Fry</name>`. Whitespace between markup is considered
+// insignificant and replaced, so documents relying on mixed content or
+// `xml:space="preserve"` may not be reproduced exactly.
+type XMLFormatter struct {
+	// Indent is the string used for each level of indentation, e.g. "  "
+	// or "\t".
+	Indent string
+	// TrailingNewline writes a newline after the final item.
+	TrailingNewline bool
+}
+
+// xmlItem is a buffered piece of markup or text.
+type xmlItem struct {
+	// Tokens holds the tokens making up the item.
+	Tokens []Token
+	// Text is set if the item is character data rather than markup.
+	Text bool
+}
+
+// opens returns true if the item is a start tag expecting an end tag.
+func (i xmlItem) opens() bool {
+	return !i.Text && i.Tokens[0].Value == "<" &&
+		i.Tokens[len(i.Tokens)-1].Value == ">"
+}
+
+// closes returns true if the item is an end tag.
+func (i xmlItem) closes() bool {
+	return !i.Text && i.Tokens[0].Value == "</"
+}
+
+func (f *XMLFormatter) Filter(emit func(Token) error) func(Token) error {
+	// item holds the item currently being read
+	var item *xmlItem
+	// text holds the text of an element whose start tag has just been
+	// written, in case its end tag follows immediately
+	var text *xmlItem
+	// inside is set once the lexer has entered the current markup, after
+	// which a token from the root state begins a new item
+	inside := false
+	// open is set if the last item written was a start tag
+	open := false
+	// written is set once the first item has been written
+	written := false
+	depth := 0
+
+	// line writes the given item on a new line
+	line := func(i *xmlItem, depth int) error {
+		if written {
+			if err := f.newline(depth, emit); err != nil {
+				return err
+			}
+		}
+		written = true
+		return f.write(i, emit)
+	}
+
+	// flush writes out the text of the current element, if any
+	flush := func() error {
+		if text == nil {
+			return nil
+		}
+		t := text
+		text = nil
+		return line(t, depth)
+	}
+
+	// end writes out a complete item
+	end := func() error {
+		i := item
+		item = nil
+		if i == nil {
+			return nil
+		}
+
+		if i.Text {
+			// Trim surrounding whitespace, dropping whitespace-only text
+			for len(i.Tokens) > 0 && i.Tokens[0].Type == Whitespace {
+				i.Tokens = i.Tokens[1:]
+			}
+			for n := len(i.Tokens); n > 0 &&
+				i.Tokens[n-1].Type == Whitespace; n-- {
+				i.Tokens = i.Tokens[:n-1]
+			}
+			if len(i.Tokens) == 0 {
+				return nil
+			}
+			if open && text == nil {
+				text = i
+				return nil
+			}
+			if err := flush(); err != nil {
+				return err
+			}
+			open = false
+			return line(i, depth)
+		}
+
+		if i.closes() {
+			if depth > 0 {
+				depth--
+			}
+			if open {
+				// Element contains only text; keep it on one line
+				open = false
+				if text != nil {
+					t := text
+					text = nil
+					if err := f.write(t, emit); err != nil {
+						return err
+					}
+				}
+				return f.write(i, emit)
+			}
+			if err := flush(); err != nil {
+				return err
+			}
+			return line(i, depth)
+		}
+
+		if err := flush(); err != nil {
+			return err
+		}
+		if err := line(i, depth); err != nil {
+			return err
+		}
+		open = i.opens()
+		if open {
+			depth++
+		}
+		return nil
+	}
+
+	return func(t Token) error {
+		if t.Type == "" {
+			if err := end(); err != nil {
+				return err
+			}
+			if err := flush(); err != nil {
+				return err
+			}
+			if written && f.TrailingNewline {
+				if err := emit(Token{Type: Whitespace, Value: "\n"}); err != nil {
+					return err
+				}
+			}
+			return emit(t)
+		}
+
+		if t.State != "root" || (item != nil && !item.Text && !inside) {
+			// Continuation of the current markup
+			if item == nil {
+				item = &xmlItem{}
+			}
+			item.Tokens = append(item.Tokens, t)
+			inside = inside || t.State != "root"
+			return nil
+		}
+
+		isText := t.Type == Text || t.Type == Literal ||
+			t.Type == Whitespace || t.Type == Error
+		if item == nil || item.Text != isText || !isText {
+			if err := end(); err != nil {
+				return err
+			}
+			item = &xmlItem{Text: isText}
+			inside = false
+		}
+		item.Tokens = append(item.Tokens, t)
+		return nil
+	}
+}
+
+// write emits the tokens of the given item, normalising any whitespace
+// within markup to a single space, or removing it before the end of a tag.
+// Whitespace within the internal subset of a DOCTYPE is left as-is.
+func (f *XMLFormatter) write(i *xmlItem, emit func(Token) error) error {
+	for n, t := range i.Tokens {
+		if t.Type == Whitespace && t.State != "internalSubset" {
+			if n+1 < len(i.Tokens) && (i.Tokens[n+1].Value == ">" ||
+				i.Tokens[n+1].Type == Whitespace) {
+				continue
+			}
+			t.Value = " "
+		}
+		if err := emit(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newline emits a newline, followed by indentation for the given depth.
+func (f *XMLFormatter) newline(depth int, emit func(Token) error) error {
+	return emitAll(emit,
+		Token{Type: Whitespace, Value: "\n"},
+		Token{Type: Whitespace, Value: strings.Repeat(f.Indent, depth)})
+}
+
+func init() {
+	Register(XML.Name, XML)
+}
@@ -0,0 +1,124 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerXML(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{`<?xml version="1.0"?>`, []Token{
+			{Value: "<?", Type: Punctuation},
+			{Value: "xml", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "version", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: `"`, Type: Punctuation},
+			{Value: "1.0", Type: String},
+			{Value: `"`, Type: Punctuation},
+			{Value: "?>", Type: Punctuation},
+		}},
+		{`<a:b xmlns:a='urn:x'>x &amp; y</a:b >`, []Token{
+			{Value: "<", Type: Punctuation},
+			{Value: "a", Type: Tag},
+			{Value: ":", Type: Punctuation},
+			{Value: "b", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "xmlns", Type: Attribute},
+			{Value: ":", Type: Punctuation},
+			{Value: "a", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: "'", Type: Punctuation},
+			{Value: "urn:x", Type: String},
+			{Value: "'", Type: Punctuation},
+			{Value: ">", Type: Punctuation},
+			{Value: "x", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "&amp;", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: "y", Type: Text},
+			{Value: "</", Type: Punctuation},
+			{Value: "a", Type: Tag},
+			{Value: ":", Type: Punctuation},
+			{Value: "b", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: ">", Type: Punctuation},
+		}},
+		{"<![CDATA[<a> ]] ]]><!-- c -->", []Token{
+			{Value: "<![CDATA[", Type: Punctuation},
+			{Value: "<a> ", Type: Text},
+			{Value: "]", Type: Text},
+			{Value: "]", Type: Text},
+			{Value: " ", Type: Text},
+			{Value: "]]>", Type: Punctuation},
+			{Value: "<!--", Type: Comment},
+			{Value: " c ", Type: Comment},
+			{Value: "-->", Type: Comment},
+		}},
+		{"<!DOCTYPE a [\n<!ENTITY e \"&#65;\">\n]>", []Token{
+			{Value: "<!", Type: Punctuation},
+			{Value: "DOCTYPE", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "a", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "[", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "<!", Type: Punctuation},
+			{Value: "ENTITY", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "e", Type: Attribute},
+			{Value: " ", Type: Whitespace},
+			{Value: `"&#65;"`, Type: String},
+			{Value: ">", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "]", Type: Punctuation},
+			{Value: ">", Type: Punctuation},
+		}},
+		{"<a\n  b=\"1\"/>&bad", []Token{
+			{Value: "<", Type: Punctuation},
+			{Value: "a", Type: Tag},
+			{Value: "\n", Type: Whitespace},
+			{Value: "  ", Type: Whitespace},
+			{Value: "b", Type: Attribute},
+			{Value: "=", Type: Assignment},
+			{Value: `"`, Type: Punctuation},
+			{Value: "1", Type: String},
+			{Value: `"`, Type: Punctuation},
+			{Value: "/>", Type: Punctuation},
+			{Value: "&", Type: Error},
+			{Value: "bad", Type: Text},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.XML, item.Subject),
+			item.Subject)
+	}
+}
+
+func TestXMLFormatter(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Format  string
+	}{
+		{`<a/>`, `<a/>`},
+		{"<a>  text  </a>", "<a>text</a>"},
+		{"<a><b>1</b><c  x='y' /></a >",
+			"<a>\n  <b>1</b>\n  <c x='y' />\n</a>"},
+		{"<?xml version=\"1.0\"?>\n\n<a>\n<!-- x -->\n\n<b></b></a>",
+			"<?xml version=\"1.0\"?>\n<a>\n  <!-- x -->\n  <b></b>\n</a>"},
+		{"<p>one <b>two</b> three</p>",
+			"<p>\n  one\n  <b>two</b>\n  three\n</p>"},
+		{"<a\n   b=\"1\"\n   c=\"2\"><![CDATA[ x ]]></a>",
+			"<a b=\"1\" c=\"2\">\n  <![CDATA[ x ]]>\n</a>"},
+	} {
+		formatted := render(t, lexers.XML.Format, item.Subject)
+		assert.Equal(t, item.Format, formatted, item.Subject)
+		assert.Equal(t, formatted, render(t, lexers.XML.Format, formatted),
+			"formatting should be idempotent")
+	}
+}
@@ -0,0 +1,166 @@
+package lexers
+
+import (
+	"regexp"
+	"strings"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+// YAML plain scalars; the first character may not be an indicator, unless
+// it is one of `-?:` followed by a non-space character. Spaces are allowed
+// within, but not before a comment or value indicator.
+const (
+	yamlPlainStart = `(?:[^\s\-?:,\[\]{}#&*!|>'"%@\x60]|[-?:]\S)`
+	yamlPlain      = yamlPlainStart + `(?:[^\s:#]|:\S|#|[ \t]+[^\s:#])*`
+	yamlFlowPlain  = yamlPlainStart +
+		`(?:[^\s:#,\[\]{}]|:[^\s,\[\]{}]|#|[ \t]+[^\s:#,\[\]{}])*`
+	// yamlSeparator matches the whitespace required after an indicator
+	yamlSeparator = `([ \t]+|\r?\n|$)`
+)
+
+var YAML = Lexer{
+	Name:    "yaml",
+	Aliases: []string{"yml"},
+	MimeTypes: []string{"application/yaml", "application/x-yaml",
+		"text/yaml", "text/x-yaml"},
+	Filenames: []string{"*.yaml", "*.yml"},
+	States: StatesSpec{
+		"root": {
+			// Document start and end markers
+			{Regexp: `(---|\.\.\.)` + yamlSeparator,
+				SubTypes: []TokenType{Punctuation, Whitespace}},
+			// Directives, e.g. `%YAML 1.2`
+			{Regexp: `(%)(\w+)([^\r\n]*)`,
+				SubTypes: []TokenType{Punctuation, Tag, Text}},
+			{Include: "block"},
+		},
+		"whitespace": {
+			{Regexp: `\s+`, Type: Whitespace},
+			{Regexp: `#[^\r\n]*`, Type: Comment},
+		},
+		// properties matches node tags, anchors and aliases
+		"properties": {
+			{Regexp: `!(?:<[^>\s]*>|[\w-]*!?[^\s,\[\]{}]*)`, Type: Tag},
+			{Regexp: `[&*][^\s,\[\]{}]+`, Type: Literal},
+		},
+		// block matches nodes in the block context, where structure is
+		// determined by indentation
+		"block": {
+			{Include: "whitespace"},
+			{Regexp: `(-)` + yamlSeparator,
+				SubTypes: []TokenType{Punctuation, Whitespace}},
+			{Regexp: `(\?)` + yamlSeparator,
+				SubTypes: []TokenType{Punctuation, Whitespace}},
+			{Regexp: `(:)` + yamlSeparator,
+				SubTypes: []TokenType{Assignment, Whitespace}},
+			{Include: "properties"},
+			// Block scalar header, e.g. `|`, `>-` or `|2+`
+			{Regexp: `([|>])([1-9][+-]?|[+-][1-9]?)?([ \t]*)(#[^\r\n]*)?` +
+				`(\r?\n|$)`,
+				SubTypes: []TokenType{Punctuation, Number, Whitespace, Comment,
+					Whitespace},
+				State: "blockScalar"},
+			{Include: "keys"},
+			{Regexp: `(` + yamlPlain + `)([ \t]*)(:)` + yamlSeparator,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment,
+					Whitespace}},
+			{Include: "scalars"},
+			{Regexp: yamlPlain, Type: Text},
+		},
+		// keys matches quoted mapping keys
+		"keys": {
+			{Regexp: `(")((?:[^"\\]|\\.)*)(")([ \t]*)(:)`,
+				SubTypes: []TokenType{Punctuation, Attribute, Punctuation,
+					Whitespace, Assignment}},
+			{Regexp: `(')((?:[^']|'')*)(')([ \t]*)(:)`,
+				SubTypes: []TokenType{Punctuation, Attribute, Punctuation,
+					Whitespace, Assignment}},
+		},
+		// scalars matches the start of quoted scalars and flow collections
+		"scalars": {
+			{Regexp: `"`, Type: Punctuation, State: "doubleQuoted"},
+			{Regexp: `'`, Type: Punctuation, State: "singleQuoted"},
+			{Regexp: `\[|\{`, Type: Punctuation, State: "flow"},
+		},
+		// flow matches nodes within a `[...]` or `{...}` collection, which
+		// may span several lines
+		"flow": {
+			{Include: "whitespace"},
+			{Regexp: `\]|\}`, Type: Punctuation, State: "#pop"},
+			{Regexp: `,`, Type: Punctuation},
+			{Regexp: `\?`, Type: Punctuation},
+			{Regexp: `:`, Type: Assignment},
+			{Include: "properties"},
+			{Include: "keys"},
+			{Regexp: `(` + yamlFlowPlain + `)([ \t]*)(:)`,
+				SubTypes: []TokenType{Attribute, Whitespace, Assignment}},
+			{Include: "scalars"},
+			{Regexp: yamlFlowPlain, Type: Text},
+		},
+		"doubleQuoted": {
+			{Regexp: `"`, Type: Punctuation, State: "#pop"},
+			{Regexp: `(?:[^"\\]|\\[\s\S])+`, Type: String},
+		},
+		"singleQuoted": {
+			{Regexp: `'`, Type: Punctuation, State: "#pop"},
+			{Regexp: `(?:[^']|'')+`, Type: String},
+		},
+		// blockScalar matches the content of a literal or folded scalar,
+		// ending at the first line indented no further than the node that
+		// owns it.
+		"blockScalar": {
+			{Regexp: `[^\r\n]+`, Type: String},
+			{Regexp: `\r?\n`, Type: Whitespace},
+		},
+	},
+	IndentStates: []string{"blockScalar"},
+	Indent:       yamlIndent,
+	Filters:      []Filter{RemoveEmptiesFilter, yamlScalarFilter},
+}
+
+var (
+	yamlNumber = regexp.MustCompile(`^(?:[-+]?[0-9]+|0o[0-7]+|` +
+		`0x[0-9a-fA-F]+|[-+]?(?:\.[0-9]+|[0-9]+(?:\.[0-9]*)?)` +
+		`(?:[eE][-+]?[0-9]+)?|[-+]?\.(?:inf|Inf|INF)|\.(?:nan|NaN|NAN))$`)
+	yamlLiteral = regexp.MustCompile(`^(?:null|Null|NULL|~|true|True|TRUE|` +
+		`false|False|FALSE)$`)
+)
+
+// yamlEntry matches the indicator of a compact sequence entry or mapping
+// key, e.g. the `- ` of `- name: |`, unless a block scalar header follows.
+var yamlEntry = regexp.MustCompile(`^[-?][ \t]+[^|>\s]`)
+
+// yamlIndent returns the column of the node on the given line, counting
+// the indicators of compact sequences and mappings as indentation, such
+// that the scalar in `- name: |` is measured from `name`.
+func yamlIndent(line string) int {
+	n := len(line) - len(strings.TrimLeft(line, " "))
+	for {
+		m := yamlEntry.FindString(line[n:])
+		if m == "" {
+			return n
+		}
+		n += len(m) - 1
+	}
+}
+
+// yamlScalarFilter resolves plain scalars that are numbers, booleans or null
+// according to the YAML core schema.
+var yamlScalarFilter = FilterFunc(
+	func(out func(Token) error) func(Token) error {
+		return func(t Token) error {
+			if t.Type == Text {
+				if yamlNumber.MatchString(t.Value) {
+					t.Type = Number
+				} else if yamlLiteral.MatchString(t.Value) {
+					t.Type = Literal
+				}
+			}
+			return out(t)
+		}
+	})
+
+func init() {
+	Register(YAML.Name, YAML)
+}
@@ -0,0 +1,129 @@
+package lexers_test
+
+import (
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerYAML(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Tokens  []Token
+	}{
+		{"---\nkey: value # note\n", []Token{
+			{Value: "---", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "key", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "value", Type: Text},
+			{Value: " ", Type: Whitespace},
+			{Value: "# note", Type: Comment},
+			{Value: "\n", Type: Whitespace},
+		}},
+		{"- 1.5\n- ~\n- &a !!str yes\n- *a", []Token{
+			{Value: "-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "1.5", Type: Number},
+			{Value: "\n", Type: Whitespace},
+			{Value: "-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "~", Type: Literal},
+			{Value: "\n", Type: Whitespace},
+			{Value: "-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "&a", Type: Literal},
+			{Value: " ", Type: Whitespace},
+			{Value: "!!str", Type: Tag},
+			{Value: " ", Type: Whitespace},
+			{Value: "yes", Type: Text},
+			{Value: "\n", Type: Whitespace},
+			{Value: "-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "*a", Type: Literal},
+		}},
+		{`{"a": [b, 'c''d'], e: true}`, []Token{
+			{Value: "{", Type: Punctuation},
+			{Value: `"`, Type: Punctuation},
+			{Value: "a", Type: Attribute},
+			{Value: `"`, Type: Punctuation},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "[", Type: Punctuation},
+			{Value: "b", Type: Text},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "'", Type: Punctuation},
+			{Value: "c''d", Type: String},
+			{Value: "'", Type: Punctuation},
+			{Value: "]", Type: Punctuation},
+			{Value: ",", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "e", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "true", Type: Literal},
+			{Value: "}", Type: Punctuation},
+		}},
+		{"a:\n  b: |+ # keep\n    x: 1\n\n   y\n  c: 2", []Token{
+			{Value: "a", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "  ", Type: Whitespace},
+			{Value: "b", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Punctuation},
+			{Value: "+", Type: Number},
+			{Value: " ", Type: Whitespace},
+			{Value: "# keep", Type: Comment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "    x: 1", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "\n", Type: Whitespace},
+			{Value: "   y", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "  ", Type: Whitespace},
+			{Value: "c", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "2", Type: Number},
+		}},
+		{"items:\n  - name: |\n      foo\n    other: 1\n- |\n  x\n- y", []Token{
+			{Value: "items", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: "\n", Type: Whitespace},
+			{Value: "  ", Type: Whitespace},
+			{Value: "-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "name", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "      foo", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "    ", Type: Whitespace},
+			{Value: "other", Type: Attribute},
+			{Value: ":", Type: Assignment},
+			{Value: " ", Type: Whitespace},
+			{Value: "1", Type: Number},
+			{Value: "\n", Type: Whitespace},
+			{Value: "-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "|", Type: Punctuation},
+			{Value: "\n", Type: Whitespace},
+			{Value: "  x", Type: String},
+			{Value: "\n", Type: Whitespace},
+			{Value: "-", Type: Punctuation},
+			{Value: " ", Type: Whitespace},
+			{Value: "y", Type: Text},
+		}},
+	} {
+		assert.Equal(t, item.Tokens, tokenize(t, lexers.YAML, item.Subject),
+			item.Subject)
+	}
+}
@@ -0,0 +1,39 @@
+package lexers_test
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/stretchr/testify/assert"
+)
+
+// render runs the given Tokenizer method over s and returns the
+// concatenated token values.
+func render(t *testing.T,
+	f func(*bufio.Reader, func(Token) error) error, s string) string {
+	var b strings.Builder
+	err := f(bufio.NewReader(strings.NewReader(s)), func(t Token) error {
+		b.WriteString(t.Value)
+		return nil
+	})
+	assert.Equal(t, io.EOF, err, "tokenizer should return EOF")
+	return b.String()
+}
+
+// tokenize tokenizes s with the given Tokenizer, returning the value and
+// type of each token emitted, excluding the final EndToken.
+func tokenize(t *testing.T, tokenizer Tokenizer, s string) []Token {
+	tokens := []Token{}
+	err := tokenizer.Tokenize(bufio.NewReader(strings.NewReader(s)),
+		func(t Token) error {
+			if t != EndToken {
+				tokens = append(tokens, Token{Value: t.Value, Type: t.Type})
+			}
+			return nil
+		})
+	assert.Equal(t, io.EOF, err, "tokenizer should return EOF")
+	return tokens
+}
@@ -0,0 +1,110 @@
+package lexers_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/johnsto/go-highlight"
+	"github.com/johnsto/go-highlight/lexers"
+	"github.com/stretchr/testify/assert"
+)
+
+// significantTokens tokenizes s, returning its tokens other than whitespace
+// and comments, with any runs of whitespace within their values collapsed.
+func significantTokens(t *testing.T, tokenizer Tokenizer, s string) []Token {
+	tokens := []Token{}
+	for _, token := range tokenize(t, tokenizer, s) {
+		token.Value = strings.Join(strings.Fields(token.Value), " ")
+		if token.Type != Whitespace && token.Type != Comment &&
+			token.Value != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func TestMinifyJSON(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Minify  string
+	}{
+		{`{}`, `{}`},
+		{"{ }", `{}`},
+		{"[ 1, 2,\n 3 ]", `[1,2,3]`},
+		{`{ "a b" : "c  d" }`, `{"a b":"c  d"}`},
+		{"{\n  \"key\": [1, \"value\", {\"a\": \"b\"}],\n  \"x\": null\n}",
+			`{"key":[1,"value",{"a":"b"}],"x":null}`},
+	} {
+		minified := render(t, lexers.JSON.Minify, item.Subject)
+		assert.Equal(t, item.Minify, minified, item.Subject)
+
+		// Minifying formatted output should reproduce the same result
+		formatted := render(t, lexers.JSON.Format, item.Subject)
+		assert.Equal(t, item.Minify, render(t, lexers.JSON.Minify, formatted),
+			item.Subject)
+
+		// ...and the document should mean the same thing
+		var expected, actual interface{}
+		assert.Nil(t, json.Unmarshal([]byte(item.Subject), &expected))
+		assert.Nil(t, json.Unmarshal([]byte(minified), &actual))
+		assert.Equal(t, expected, actual, item.Subject)
+	}
+}
+
+func TestMinifyCSS(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Minify  string
+	}{
+		{"a { color: red; }", `a{color:red;}`},
+		{"div p,\n.x > .y {\n  margin: 0  auto;\n}\n",
+			`div p,.x>.y{margin:0 auto;}`},
+		{"/* note */\nh1 {\n  font: 'A  B', serif; /* why */\n}",
+			`h1{font:'A  B',serif;}`},
+		{"@media screen and (max-width: 100px) {\n  a { top: 0; }\n}",
+			`@media screen and (max-width:100px){a{top:0;}}`},
+		{"a { width: calc(100% - 2 * 1.5em) !important; }",
+			`a{width:calc(100% - 2 * 1.5em)!important;}`},
+		{".a {\n  &:hover { color: #fff }\n  --x: 1px;\n}",
+			`.a{&:hover{color:#fff}--x:1px;}`},
+	} {
+		minified := render(t, lexers.CSS.Minify, item.Subject)
+		assert.Equal(t, item.Minify, minified, item.Subject)
+		assert.Equal(t, minified, render(t, lexers.CSS.Minify, minified),
+			"minifying should be idempotent")
+
+		// Minifying should only remove insignificant tokens
+		assert.Equal(t, significantTokens(t, lexers.CSS, item.Subject),
+			significantTokens(t, lexers.CSS, minified), item.Subject)
+	}
+}
+
+func TestMinifyHTML(t *testing.T) {
+	for _, item := range []struct {
+		Subject string
+		Minify  string
+	}{
+		{"<p>Hello,   <b>world</b>!</p>", `<p>Hello, <b>world</b>!</p>`},
+		{"<div\n  class=\"a\"   id=x >\n  <!-- hidden -->\n  text\n</div >",
+			`<div class="a" id=x> text </div>`},
+		{"<br />", `<br />`},
+		{"<pre>  keep\n    this </pre>\n\n<p> a  b </p>",
+			"<pre>  keep\n    this </pre> <p> a b </p>"},
+		{"<textarea>\n  x  </textarea>", "<textarea>\n  x  </textarea>"},
+		{"<p title=\"a  b\"\n   hidden>x</p>", `<p title="a  b" hidden>x</p>`},
+		{"<!--[if IE]> <p>IE</p> <![endif]--><!-- gone -->",
+			"<!--[if IE]> <p>IE</p> <![endif]-->"},
+		{"<style>\n  a { top: 0; }\n</style>",
+			"<style>\n  a { top: 0; }\n</style>"},
+	} {
+		minified := render(t, lexers.HTML.Minify, item.Subject)
+		assert.Equal(t, item.Minify, minified, item.Subject)
+		assert.Equal(t, minified, render(t, lexers.HTML.Minify, minified),
+			"minifying should be idempotent")
+
+		// Minifying should only remove insignificant tokens
+		assert.Equal(t, significantTokens(t, lexers.HTML, item.Subject),
+			significantTokens(t, lexers.HTML, minified), item.Subject)
+	}
+}
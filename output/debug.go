@@ -10,6 +10,8 @@ import (
 
 type DebugOutputter struct {
 	Writer io.Writer
+	// ShowPath adds a column containing the path of each token.
+	ShowPath bool
 }
 
 func NewDebugOutputter() *DebugOutputter {
@@ -24,6 +26,11 @@ func (o *DebugOutputter) SetFile(f *os.File) error {
 }
 
 func (o *DebugOutputter) Emit(t highlight.Token) error {
+	if o.ShowPath {
+		_, err := fmt.Fprintf(o.Writer,
+			"%24s\t%12s\t%-24s\t%#v\n", t.State, t.Type, t.Path, t.Value)
+		return err
+	}
 	_, err := fmt.Fprintf(o.Writer,
 		"%24s\t%12s\t%#v\n", t.State, t.Type, t.Value)
 	return err
@@ -9,6 +9,11 @@ import (
 
 type Output struct {
 	Colors map[highlight.TokenType]*color.Color
+	// Levels overrides the colour of Level tokens by their Severity.
+	Levels map[string]*color.Color
+	// Columns cycles through colours for the tokens of each column of
+	// tabular data, overriding the colour of their type.
+	Columns []*color.Color
 }
 
 func NewOutput() *Output {
@@ -24,14 +29,42 @@ func NewOutput() *Output {
 			highlight.Operator:    color.New(color.FgGreen),
 			highlight.Punctuation: color.New(color.FgYellow),
 			highlight.Literal:     color.New(color.FgBlue, color.Bold),
+			highlight.Keyword:     color.New(color.FgCyan, color.Bold),
 			highlight.Tag:         color.New(color.FgHiYellow),
 			highlight.Whitespace:  color.New(color.FgWhite),
+			highlight.Inserted:    color.New(color.FgGreen),
+			highlight.Deleted:     color.New(color.FgRed),
+			highlight.Level:       color.New(color.FgHiWhite, color.Bold),
+			highlight.Timestamp:   color.New(color.FgCyan),
+		},
+		Levels: map[string]*color.Color{
+			highlight.SeverityError:   color.New(color.FgHiRed, color.Bold),
+			highlight.SeverityWarning: color.New(color.FgHiYellow, color.Bold),
+			highlight.SeverityNotice:  color.New(color.FgHiCyan),
+			highlight.SeverityInfo:    color.New(color.FgHiGreen),
+			highlight.SeverityDebug:   color.New(color.FgWhite, color.Faint),
+		},
+		Columns: []*color.Color{
+			color.New(color.FgHiWhite),
+			color.New(color.FgHiCyan),
+			color.New(color.FgHiYellow),
+			color.New(color.FgHiGreen),
+			color.New(color.FgHiMagenta),
+			color.New(color.FgHiBlue),
 		},
 	}
 }
 
 func (o *Output) Emit(t highlight.Token) error {
 	c := o.Colors[t.Type]
+	if t.Type == highlight.Level {
+		if lc := o.Levels[highlight.Severity(t.Value)]; lc != nil {
+			c = lc
+		}
+	}
+	if t.Column > 0 && len(o.Columns) > 0 {
+		c = o.Columns[(t.Column-1)%len(o.Columns)]
+	}
 	if c == nil {
 		_, err := o.Colors[highlight.Error].Printf("%s", t.Value)
 		return err
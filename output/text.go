@@ -24,6 +24,6 @@ func (o *TextOutputter) SetFile(f *os.File) error {
 }
 
 func (o *TextOutputter) Emit(t highlight.Token) error {
-	_, err := fmt.Fprintf(o.Writer, t.Value)
+	_, err := fmt.Fprint(o.Writer, t.Value)
 	return err
 }
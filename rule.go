@@ -16,6 +16,8 @@ type (
 	// Rule describes the conditions required to match some subject text.
 	RuleSpec struct {
 		// Regexp is the regular expression this rule should match against.
+		// An empty Regexp always matches without consuming any input, which
+		// allows a final rule to change state when no other rule matches.
 		Regexp string
 		// Type is the token type for strings that match this rule.
 		Type TokenType
@@ -23,10 +25,18 @@ type (
 		// of groups in the Regexp expression.
 		SubTypes []TokenType
 		// State indicates the next state to migrate to if this rule is
-		// triggered.
+		// triggered. Multiple states may be separated by spaces, and are
+		// applied in order; `#pop` returns to the previous state, and
+		// `#reset` returns to the root state.
 		State string
 		// Include specifies a state to run
 		Include string
+		// Capture optionally numbers a group of Regexp, counting from 1,
+		// whose text is passed to the states entered by this rule. Within
+		// the rules of those states, `{captured}` stands for the text, so
+		// that a state may end at a terminator chosen by the input, such as
+		// the delimiter of a here-document.
+		Capture int
 	}
 
 	// IncludeRule allows the states of another Rule to be referenced.
@@ -41,6 +51,8 @@ type (
 		Type       TokenType
 		SubTypes   []TokenType
 		NextStates []string
+		// Capture is the group whose text is passed to NextStates, if any.
+		Capture int
 	}
 )
 
@@ -52,8 +64,10 @@ func (rs RuleSpec) Compile(sm *StateMap) Rule {
 			StateName: rs.Include,
 		}
 	}
-	return NewRegexpRule(rs.Regexp, rs.Type, rs.SubTypes,
+	rule := NewRegexpRule(rs.Regexp, rs.Type, rs.SubTypes,
 		strings.Split(rs.State, " "))
+	rule.Capture = rs.Capture
+	return rule
 }
 
 // NewRegexpRule creates a new regular expression Rule.
@@ -86,6 +100,10 @@ func (r RegexpRule) Find(subject string) (int, Rule) {
 func (r RegexpRule) Match(subject string) (int, Rule, []Token, error) {
 	// Find match group and sub groups, returns an array of start/end offsets
 	// e.g. f(r/a(b+)c/g, "abbbc") = [0, 5, 1, 4]
+	if r.Regexp.String() == "" {
+		return 0, r, nil, nil
+	}
+
 	indices := r.Regexp.FindStringSubmatchIndex(subject)
 
 	if indices == nil || indices[0] != 0 || indices[1] == 0 {
@@ -95,6 +113,7 @@ func (r RegexpRule) Match(subject string) (int, Rule, []Token, error) {
 
 	// Get position after final matched character
 	n := indices[1]
+	r = r.captured(subject, indices)
 
 	if r.SubTypes == nil {
 		// No groups in expression; return single token and type
@@ -144,6 +163,26 @@ func (r RegexpRule) Match(subject string) (int, Rule, []Token, error) {
 	return n, r, tokens, nil
 }
 
+// captured returns a copy of the rule in which the text of its Capture
+// group, if matched, is passed to each of the states it enters, e.g.
+// `heredoc:EOF`.
+func (r RegexpRule) captured(subject string, indices []int) RegexpRule {
+	i := r.Capture * 2
+	if r.Capture <= 0 || i+1 >= len(indices) || indices[i] < 0 {
+		return r
+	}
+	text := subject[indices[i]:indices[i+1]]
+	next := make([]string, len(r.NextStates))
+	for j, state := range r.NextStates {
+		if state != "" && !strings.HasPrefix(state, "#") {
+			state += ":" + text
+		}
+		next[j] = state
+	}
+	r.NextStates = next
+	return r
+}
+
 func (r RegexpRule) Stack() []string {
 	return r.NextStates
 }
@@ -46,18 +46,23 @@ func TestRegexpRuleMatch(t *testing.T) {
 		// Non-matching
 		{"ab+c", Text, nil, "", -1, nil},
 		// Simple matching
-		{"ab+c", Text, nil, "abc", 3, []Token{{"abc", Text, ""}}},
-		{"ab+c", Text, nil, "abbbc", 5, []Token{{"abbbc", Text, ""}}},
+		{"ab+c", Text, nil, "abc", 3,
+			[]Token{{Value: "abc", Type: Text}}},
+		{"ab+c", Text, nil, "abbbc", 5,
+			[]Token{{Value: "abbbc", Type: Text}}},
 		// Non-matching subgroup
 		{"(b+)(c+)", Error, []TokenType{Text}, "bbb", -1, nil},
 		// Simple matching subgroup
 		{"(b+)(c+)", Error, []TokenType{Text, Text}, "bbcc", 4,
-			[]Token{{"bb", Text, ""}, {"cc", Text, ""}}},
-		{"(b+)(c+)", Error, nil, "bbcc", 4, []Token{{"bbcc", Error, ""}}},
+			[]Token{{Value: "bb", Type: Text}, {Value: "cc", Type: Text}}},
+		{"(b+)(c+)", Error, nil, "bbcc", 4,
+			[]Token{{Value: "bbcc", Type: Error}}},
 		// Subgroup with outliers
 		{"a(b+)cc(d+)", Error, []TokenType{Text, Text}, "abbccddd", 8,
-			[]Token{{"a", Error, ""}, {"bb", Text, ""},
-				{"cc", Error, ""}, {"ddd", Text, ""}}},
+			[]Token{{Value: "a", Type: Error}, {Value: "bb", Type: Text},
+				{Value: "cc", Type: Error}, {Value: "ddd", Type: Text}}},
+		// Empty expression matches without consuming input
+		{"", Text, nil, "abc", 0, nil},
 	} {
 		rule := NewRegexpRule(item.Regexp, item.Type, item.Types, nil)
 		n, _, tokens, err := rule.Match(item.Subject)
@@ -1,5 +1,10 @@
 package highlight
 
+import (
+	"regexp"
+	"strings"
+)
+
 // States contains lexer states
 type States interface {
 	Get(name string) State
@@ -41,9 +46,43 @@ func (m StatesSpec) MustCompile() States {
 // StateMap is a map of states to their names.
 type StateMap map[string]State
 
-// Get returns the State with the given name.
+// Get returns the State with the given name. A name of the form
+// `name:text` refers to the named State with `{captured}` replaced by the
+// text in each of its rules, as entered by a rule with a Capture group.
 func (m StateMap) Get(name string) State {
-	return m[name]
+	if state, ok := m[name]; ok {
+		return state
+	}
+	i := strings.Index(name, ":")
+	if i < 0 {
+		return nil
+	}
+	base, ok := m[name[:i]]
+	if !ok {
+		return nil
+	}
+	text := regexp.QuoteMeta(name[i+1:])
+	state := make(State, len(base))
+	for j, rule := range base {
+		if r, ok := rule.(RegexpRule); ok &&
+			strings.Contains(r.Regexp.String(), "{captured}") {
+			r.Regexp = regexp.MustCompile(strings.Replace(r.Regexp.String(),
+				"{captured}", text, -1))
+			rule = r
+		}
+		state[j] = rule
+	}
+	m[name] = state
+	return state
+}
+
+// baseStateName returns the name of the given state without any captured
+// text, e.g. `heredoc` for `heredoc:EOF`.
+func baseStateName(name string) string {
+	if i := strings.Index(name, ":"); i >= 0 {
+		return name[:i]
+	}
+	return name
 }
 
 // Compile does nothing.
@@ -8,6 +8,13 @@ type Token struct {
 	Value string
 	Type  TokenType
 	State string
+	// Path optionally locates the token within the structure of the
+	// document, e.g. `$.items[3].name` for JSON.
+	Path string
+	// Column optionally numbers the column of tabular data the token
+	// belongs to, starting from 1, e.g. each field of a CSV record. It is
+	// 0 for tokens that aren't within a column, such as delimiters.
+	Column int
 }
 
 func (t Token) String() string {
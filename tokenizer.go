@@ -13,6 +13,16 @@ type Tokenizer interface {
 	// Format behaves exactly as Tokenize, except it also formats the output.
 	Format(*bufio.Reader, func(Token) error) error
 
+	// Minify behaves exactly as Tokenize, except it also removes any
+	// insignificant whitespace and comments from the output.
+	Minify(*bufio.Reader, func(Token) error) error
+
+	// Validate behaves exactly as Tokenize, except it also checks the
+	// structure of the input. Any offending tokens are emitted as Error,
+	// and ValidationErrors is returned in place of io.EOF if any problems
+	// were found.
+	Validate(*bufio.Reader, func(Token) error) error
+
 	// AcceptsFilename returns true if this Lexer thinks it is suitable for
 	// the given filename. An error will be returned iff an invalid filename
 	// pattern is registered by the Lexer.
@@ -30,4 +40,8 @@ type Tokenizer interface {
 	// ListFilenames lists the filename patterns this Tokenizer advertises
 	// support for, e.g. ["*.json"]
 	ListFilenames() []string
+
+	// ListAliases lists the alternative names this Tokenizer may be found
+	// by, in addition to the name it is registered under, e.g. ["postgres"]
+	ListAliases() []string
 }
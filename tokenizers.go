@@ -1,5 +1,7 @@
 package highlight
 
+import "strings"
+
 var tokenizers map[string]Tokenizer
 
 // Register registers the given Tokenizer under the specified name. Any
@@ -11,9 +13,27 @@ func Register(name string, t Tokenizer) {
 	tokenizers[name] = t
 }
 
-// GetTokenizer returns the Tokenizer of the given name.
+// GetTokenizer returns the Tokenizer of the given name, or nil if one is not
+// found. If no Tokenizer is registered under the exact name, registered
+// names and aliases are compared case-insensitively, so "JSON" and
+// "postgres" are also accepted.
 func GetTokenizer(name string) Tokenizer {
-	return tokenizers[name]
+	if tokenizer, ok := tokenizers[name]; ok {
+		return tokenizer
+	}
+	for registered, tokenizer := range tokenizers {
+		if strings.EqualFold(registered, name) {
+			return tokenizer
+		}
+	}
+	for _, tokenizer := range tokenizers {
+		for _, alias := range tokenizer.ListAliases() {
+			if strings.EqualFold(alias, name) {
+				return tokenizer
+			}
+		}
+	}
+	return nil
 }
 
 // GetTokenizers returns the map of known Tokenizers.
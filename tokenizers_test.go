@@ -0,0 +1,23 @@
+package highlight_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/johnsto/go-highlight"
+)
+
+func TestGetTokenizer(t *testing.T) {
+	lexer := Lexer{Name: "test-sql", Aliases: []string{"test-pg"}}
+	Register(lexer.Name, lexer)
+
+	for _, name := range []string{"test-sql", "TEST-SQL", "test-pg",
+		"Test-PG"} {
+		tokenizer := GetTokenizer(name)
+		if assert.NotNil(t, tokenizer, name) {
+			assert.Equal(t, "test-sql", tokenizer.(Lexer).Name, name)
+		}
+	}
+	assert.Nil(t, GetTokenizer("test-mysql"))
+}
@@ -24,10 +24,21 @@ const (
 	Punctuation = "punctuation"
 	// Literal - e.g. `true`/`false`/`null`.
 	Literal = "literal"
+	// Keyword - e.g. `if`/`return`/`function`
+	Keyword = "keyword"
 	// Tag - e.g. `html`/`div`/`b`
 	Tag = "tag"
 	// Whitespace - e.g. \n, \t
 	Whitespace = "whitespace"
+	// Inserted - e.g. `+fry` in a diff
+	Inserted = "inserted"
+	// Deleted - e.g. `-bender` in a diff
+	Deleted = "deleted"
+	// Level - e.g. `WARN` in a log line, or `404` in an access log. See
+	// Severity.
+	Level = "level"
+	// Timestamp - e.g. `2016-01-01T12:00:00Z` in a log line
+	Timestamp = "timestamp"
 )
 
 var EndToken = Token{}
@@ -0,0 +1,62 @@
+package highlight
+
+import "fmt"
+
+// ValidationError describes a structural problem found in the input by a
+// validating Filter.
+type ValidationError struct {
+	// Line and Column give the (1-based) position of the problem.
+	Line, Column int
+	// Message describes the problem.
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// ValidationErrors is a list of problems found in the input, in order.
+type ValidationErrors []ValidationError
+
+func (es ValidationErrors) Error() string {
+	switch len(es) {
+	case 0:
+		return "no errors"
+	case 1:
+		return es[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", es[0], len(es)-1)
+}
+
+// Position tracks the line and column reached within the input, given each
+// token in turn.
+type Position struct {
+	Line, Column int
+}
+
+// Advance moves the position past the given token.
+func (p *Position) Advance(t Token) {
+	if p.Line == 0 {
+		p.Line, p.Column = 1, 1
+	}
+	for _, r := range t.Value {
+		if r == '\n' {
+			p.Line++
+			p.Column = 1
+		} else {
+			p.Column++
+		}
+	}
+}
+
+// Error returns a ValidationError at the current position.
+func (p Position) Error(format string, args ...interface{}) ValidationError {
+	if p.Line == 0 {
+		p.Line, p.Column = 1, 1
+	}
+	return ValidationError{
+		Line:    p.Line,
+		Column:  p.Column,
+		Message: fmt.Sprintf(format, args...),
+	}
+}